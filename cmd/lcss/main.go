@@ -318,24 +318,24 @@ func runBuild(args []string) error {
 		return err
 	}
 
-	canonical := cfg.Canonicalize()
+	compiler := compile.NewCompiler(cfg.Canonicalize())
 	var output compile.Output
 	if *production {
 		if len(cfg.Build.Content) == 0 {
 			return errors.New("build.content is required for --production")
 		}
-		result, err := extract.FromPaths(cfg.Build.Content, cfg.Build.Safelist)
+		result, warnings, err := extractContent(cfg, compiler)
 		if err != nil {
 			return err
 		}
-		output, err = compile.Build(canonical, result)
+		output, err = compiler.Build(result)
 		if err != nil {
 			return err
 		}
+		output.Warnings = append(warnings, output.Warnings...)
 	} else {
-		classes := compile.AllClasses(canonical)
-		result := extract.Result{Classes: classes}
-		output, err = compile.Build(canonical, result)
+		result := extract.Result{Classes: compiler.AllClasses()}
+		output, err = compiler.Build(result)
 		if err != nil {
 			return err
 		}
@@ -449,10 +449,18 @@ func runScan(args []string) error {
 		return errors.New("build.content is required for scan")
 	}
 
-	result, err := extract.FromPaths(cfg.Build.Content, cfg.Build.Safelist)
+	compiler := compile.NewCompiler(cfg.Canonicalize())
+	result, warnings, err := extractContent(cfg, compiler)
+	if err != nil {
+		return err
+	}
+	result, blockWarnings, err := compiler.ApplyBlocklist(result)
 	if err != nil {
 		return err
 	}
+	for _, warning := range append(warnings, blockWarnings...) {
+		_, _ = fmt.Fprintln(os.Stderr, "warning:", warning)
+	}
 
 	_, _ = fmt.Fprintf(os.Stdout, "Files: %d\n", result.Files)
 	_, _ = fmt.Fprintf(os.Stdout, "Classes: %d\n", len(result.Classes))
@@ -512,7 +520,7 @@ func runScan(args []string) error {
 			continue
 		}
 
-		_, _ = fmt.Fprintf(os.Stdout, "%s:\n", name)
+		_, _ = fmt.Fprintf(os.Stdout, "%s (%s):\n", name, result.Extractors[name])
 		for _, item := range perFileItems[:perFileLimit] {
 			_, _ = fmt.Fprintf(os.Stdout, "  %s (%d)\n", item.Class, item.Count)
 		}
@@ -525,6 +533,27 @@ func printScanUsage() {
 	_, _ = fmt.Fprintln(os.Stdout, "  lcss scan [--site <path>] [--top <n>] [--per-file]")
 }
 
+func extractContent(cfg config.Config, compiler *compile.Compiler) (extract.Result, []string, error) {
+	safelist, warnings, err := compiler.ExpandSafelist(cfg.Build.Safelist)
+	if err != nil {
+		return extract.Result{}, nil, err
+	}
+	result, err := extract.FromPaths(cfg.Build.Content, safelist, extractOptions(cfg))
+	if err != nil {
+		return extract.Result{}, nil, err
+	}
+	return result, append(warnings, result.Warnings...), nil
+}
+
+func extractOptions(cfg config.Config) extract.Options {
+	return extract.Options{
+		Extractors:     cfg.Build.Extractors,
+		ClassFunctions: cfg.Build.ClassFunctions,
+		DataSelectors:  cfg.Build.DataSelectors,
+		Workers:        cfg.Build.Workers,
+	}
+}
+
 type classCount struct {
 	Class string
 	Count int
@@ -585,16 +614,17 @@ func watchOnce(basePath, sitePath, outPath, cachePath string, state *watchState)
 		return false, nil
 	}
 
-	result, err := extract.FromPaths(cfg.Build.Content, cfg.Build.Safelist)
+	compiler := compile.NewCompiler(cfg.Canonicalize())
+	result, warnings, err := extractContent(cfg, compiler)
 	if err != nil {
 		return false, err
 	}
 
-	canonical := cfg.Canonicalize()
-	output, err := compile.Build(canonical, result)
+	output, err := compiler.Build(result)
 	if err != nil {
 		return false, err
 	}
+	output.Warnings = append(warnings, output.Warnings...)
 	for _, warning := range output.Warnings {
 		_, _ = fmt.Fprintln(os.Stderr, "warning:", warning)
 	}
@@ -0,0 +1,251 @@
+package compile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"lcss/internal/config"
+	"lcss/internal/extract"
+)
+
+// Compiler runs one build against a canonical config. It expands AllClasses at
+// most once, so the safelist, the blocklist, and development builds share the
+// same list; create a new Compiler for each build or watch rebuild.
+type Compiler struct {
+	canonical config.Canonical
+	all       []string
+	known     map[string]struct{}
+}
+
+func NewCompiler(canonical config.Canonical) *Compiler {
+	return &Compiler{canonical: canonical}
+}
+
+// AllClasses returns every class the config can generate.
+func (c *Compiler) AllClasses() []string {
+	if c.all == nil {
+		c.all = AllClasses(c.canonical)
+		c.known = classNameSet(c.all)
+	}
+	return c.all
+}
+
+// classPattern is one safelist or blocklist entry. Entries take three forms,
+// and braces expand in all of them:
+//   - a literal class, kept as is: "bg-red-500", "{,hover:}p-4"
+//   - a glob, where * never crosses the variant separator: "text-*-500"
+//   - a regex between slashes, matched against unprefixed classes, with an
+//     optional brace prefix for variants: "/^ring-\d$/", "{,hover:}/^ring-\d$/"
+type classPattern struct {
+	entry    string
+	prefixes []string
+	expr     *regexp.Regexp
+	globs    []*regexp.Regexp
+	literals []string
+}
+
+// ExpandSafelist returns the classes listed by build.safelist entries.
+func (c *Compiler) ExpandSafelist(entries []string) ([]string, []string, error) {
+	patterns, err := parseClassPatterns("build.safelist", entries)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var classes, warnings []string
+	for _, pattern := range patterns {
+		classes = append(classes, pattern.literals...)
+		if !pattern.dynamic() {
+			continue
+		}
+		matches := pattern.match(c.AllClasses(), c.known, c.canonical.Config.Separator)
+		if len(matches) == 0 {
+			warnings = append(warnings, fmt.Sprintf("safelist pattern %s matches no classes", pattern.entry))
+		}
+		classes = append(classes, matches...)
+	}
+	return classes, warnings, nil
+}
+
+// ApplyBlocklist drops classes matched by build.blocklist from result.
+func (c *Compiler) ApplyBlocklist(result extract.Result) (extract.Result, []string, error) {
+	entries := c.canonical.Config.Build.Blocklist
+	if len(entries) == 0 {
+		return result, nil, nil
+	}
+	patterns, err := parseClassPatterns("build.blocklist", entries)
+	if err != nil {
+		return extract.Result{}, nil, err
+	}
+
+	var universe []string
+	var known map[string]struct{}
+	var warnings []string
+	blocked := map[string]struct{}{}
+	for _, pattern := range patterns {
+		for _, class := range pattern.literals {
+			blocked[class] = struct{}{}
+		}
+		if !pattern.dynamic() {
+			continue
+		}
+		if universe == nil {
+			universe = mergeClassNames(c.AllClasses(), result.Classes)
+			known = classNameSet(universe)
+		}
+		matches := pattern.match(universe, known, c.canonical.Config.Separator)
+		if len(matches) == 0 {
+			warnings = append(warnings, fmt.Sprintf("blocklist pattern %s matches no classes", pattern.entry))
+		}
+		for _, class := range matches {
+			blocked[class] = struct{}{}
+		}
+	}
+
+	filtered := result
+	filtered.Classes = make([]string, 0, len(result.Classes))
+	for _, class := range result.Classes {
+		if _, ok := blocked[class]; !ok {
+			filtered.Classes = append(filtered.Classes, class)
+		}
+	}
+	if result.Counts != nil {
+		filtered.Counts = make(map[string]int, len(result.Counts))
+		for class, count := range result.Counts {
+			if _, ok := blocked[class]; !ok {
+				filtered.Counts[class] = count
+			}
+		}
+	}
+	if result.ByFile != nil {
+		filtered.ByFile = make(map[string]map[string]int, len(result.ByFile))
+		for file, counts := range result.ByFile {
+			kept := make(map[string]int, len(counts))
+			for class, count := range counts {
+				if _, ok := blocked[class]; !ok {
+					kept[class] = count
+				}
+			}
+			filtered.ByFile[file] = kept
+		}
+	}
+	return filtered, warnings, nil
+}
+
+func parseClassPatterns(field string, entries []string) ([]classPattern, error) {
+	patterns := make([]classPattern, 0, len(entries))
+	for i, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			return nil, fmt.Errorf("%s[%d] must not be empty", field, i)
+		}
+		pattern := classPattern{entry: entry}
+		if prefix, expr, ok := splitClassRegexp(entry); ok {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("%s[%d]: invalid pattern %s: %w", field, i, entry, err)
+			}
+			pattern.expr = re
+			pattern.prefixes = extract.ExpandBraces(prefix)
+			patterns = append(patterns, pattern)
+			continue
+		}
+		for _, alternative := range extract.ExpandBraces(entry) {
+			if !strings.Contains(alternative, "*") {
+				pattern.literals = append(pattern.literals, alternative)
+				continue
+			}
+			parts := strings.Split(alternative, "*")
+			for j, part := range parts {
+				parts[j] = regexp.QuoteMeta(part)
+			}
+			pattern.globs = append(pattern.globs, regexp.MustCompile("^"+strings.Join(parts, "(.*?)")+"$"))
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+func (p classPattern) dynamic() bool {
+	return p.expr != nil || len(p.globs) > 0
+}
+
+func (p classPattern) match(universe []string, known map[string]struct{}, separator string) []string {
+	if separator == "" {
+		separator = ":"
+	}
+
+	seen := map[string]struct{}{}
+	var matches []string
+	add := func(class string) {
+		if _, ok := seen[class]; ok {
+			return
+		}
+		seen[class] = struct{}{}
+		matches = append(matches, class)
+	}
+
+	for _, class := range universe {
+		if p.expr != nil && p.expr.MatchString(class) {
+			for _, prefix := range p.prefixes {
+				if prefix == "" {
+					add(class)
+					continue
+				}
+				if _, ok := known[prefix+class]; ok {
+					add(prefix + class)
+				}
+			}
+		}
+		for _, glob := range p.globs {
+			groups := glob.FindStringSubmatch(class)
+			if groups == nil {
+				continue
+			}
+			wildcardVariant := false
+			for _, group := range groups[1:] {
+				if strings.Contains(group, separator) {
+					wildcardVariant = true
+					break
+				}
+			}
+			if !wildcardVariant {
+				add(class)
+			}
+		}
+	}
+	return matches
+}
+
+func splitClassRegexp(entry string) (string, string, bool) {
+	if len(entry) < 3 || !strings.HasSuffix(entry, "/") {
+		return "", "", false
+	}
+	start := strings.Index(entry, "/")
+	if start == len(entry)-1 {
+		return "", "", false
+	}
+	return entry[:start], entry[start+1 : len(entry)-1], true
+}
+
+func classNameSet(classes []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(classes))
+	for _, class := range classes {
+		set[class] = struct{}{}
+	}
+	return set
+}
+
+func mergeClassNames(lists ...[]string) []string {
+	seen := map[string]struct{}{}
+	var merged []string
+	for _, list := range lists {
+		for _, class := range list {
+			if _, ok := seen[class]; !ok {
+				seen[class] = struct{}{}
+				merged = append(merged, class)
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,155 @@
+package compile
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"lcss/internal/config"
+	"lcss/internal/extract"
+)
+
+func newTestCompiler(t *testing.T, blocklist ...string) *Compiler {
+	t.Helper()
+	cfg, err := config.Load("", "")
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	cfg.Build.Blocklist = blocklist
+	return NewCompiler(cfg.Canonicalize())
+}
+
+func TestExpandSafelist(t *testing.T) {
+	compiler := newTestCompiler(t)
+	tests := []struct {
+		name     string
+		entries  []string
+		include  []string
+		exclude  []string
+		warnings int
+	}{
+		{
+			name:    "literal braces",
+			entries: []string{"{,hover:}p-4"},
+			include: []string{"p-4", "hover:p-4"},
+			exclude: []string{"md:p-4"},
+		},
+		{
+			name:    "glob stays out of variants",
+			entries: []string{"text-*-500"},
+			include: []string{"text-blue-500", "text-ink-500"},
+			exclude: []string{"hover:text-blue-500", "md:text-blue-500"},
+		},
+		{
+			name:    "glob with variant braces",
+			entries: []string{"{,hover:}text-*-500"},
+			include: []string{"text-blue-500", "hover:text-blue-500"},
+			exclude: []string{"md:text-blue-500"},
+		},
+		{
+			name:    "regex with variant braces",
+			entries: []string{`{,hover:}/^ring-offset-\d$/`},
+			include: []string{"ring-offset-2", "hover:ring-offset-2"},
+			exclude: []string{"md:ring-offset-2", "ring-offset-white"},
+		},
+		{
+			name:     "pattern without matches",
+			entries:  []string{"nothing-*"},
+			warnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classes, warnings, err := compiler.ExpandSafelist(tt.entries)
+			if err != nil {
+				t.Fatalf("ExpandSafelist: %v", err)
+			}
+			for _, class := range tt.include {
+				if !slices.Contains(classes, class) {
+					t.Errorf("missing %s", class)
+				}
+			}
+			for _, class := range tt.exclude {
+				if slices.Contains(classes, class) {
+					t.Errorf("unexpected %s", class)
+				}
+			}
+			if len(warnings) != tt.warnings {
+				t.Errorf("warnings = %q, want %d", warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestApplyBlocklist(t *testing.T) {
+	content := []string{"p-4", "ring-offset-2", "hover:ring-offset-2", "text-blue-500", "hover:text-blue-500"}
+	tests := []struct {
+		name      string
+		blocklist []string
+		want      []string
+	}{
+		{
+			name:      "literal blocks only that class",
+			blocklist: []string{"p-4"},
+			want:      []string{"ring-offset-2", "hover:ring-offset-2", "text-blue-500", "hover:text-blue-500"},
+		},
+		{
+			// Globs never cross the variant separator, so variants must be
+			// listed to block them.
+			name:      "glob leaves variants alone",
+			blocklist: []string{"ring-offset-*"},
+			want:      []string{"p-4", "hover:ring-offset-2", "text-blue-500", "hover:text-blue-500"},
+		},
+		{
+			name:      "glob with variant braces",
+			blocklist: []string{"{,hover:}ring-offset-*"},
+			want:      []string{"p-4", "text-blue-500", "hover:text-blue-500"},
+		},
+		{
+			name:      "regex with variant braces",
+			blocklist: []string{`{,hover:}/^text-\w+-500$/`},
+			want:      []string{"p-4", "ring-offset-2", "hover:ring-offset-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := newTestCompiler(t, tt.blocklist...)
+			result, warnings, err := compiler.ApplyBlocklist(extract.Result{Classes: content})
+			if err != nil {
+				t.Fatalf("ApplyBlocklist: %v", err)
+			}
+			if len(warnings) > 0 {
+				t.Errorf("unexpected warnings: %q", warnings)
+			}
+			if !slices.Equal(result.Classes, tt.want) {
+				t.Errorf("classes = %q, want %q", result.Classes, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassPatternErrors(t *testing.T) {
+	compiler := newTestCompiler(t, "/[/")
+	if _, _, err := compiler.ExpandSafelist([]string{"  "}); err == nil || !strings.Contains(err.Error(), "build.safelist[0] must not be empty") {
+		t.Errorf("ExpandSafelist error = %v, want an empty entry error", err)
+	}
+	if _, err := compiler.Build(extract.Result{Classes: []string{"p-4"}}); err == nil || !strings.Contains(err.Error(), "build.blocklist[0]: invalid pattern /[/") {
+		t.Errorf("Build error = %v, want an invalid pattern error", err)
+	}
+}
+
+func TestCompilerExpandsAllClassesOnce(t *testing.T) {
+	compiler := newTestCompiler(t, "ring-offset-*")
+	if _, _, err := compiler.ExpandSafelist([]string{"text-*-500"}); err != nil {
+		t.Fatalf("ExpandSafelist: %v", err)
+	}
+	first := compiler.AllClasses()
+	if _, err := compiler.Build(extract.Result{Classes: []string{"ring-offset-2"}}); err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if second := compiler.AllClasses(); &first[0] != &second[0] {
+		t.Errorf("AllClasses was expanded again")
+	}
+}
@@ -17,12 +17,24 @@ type Output struct {
 	Warnings []string
 }
 
+// Build compiles result with a fresh Compiler.
 func Build(canonical config.Canonical, result extract.Result) (Output, error) {
+	return NewCompiler(canonical).Build(result)
+}
+
+// Build applies the blocklist to result and renders the stylesheet.
+func (c *Compiler) Build(result extract.Result) (Output, error) {
+	canonical := c.canonical
 	policy := canonical.Config.Build.UnknownClassPolicy
 	if policy == "" {
 		policy = "warn"
 	}
 
+	result, blockWarnings, err := c.ApplyBlocklist(result)
+	if err != nil {
+		return Output{}, err
+	}
+
 	sections := make([]string, 0, 4)
 	if canonical.Config.Build.Emit.FontsCSS {
 		fonts := emit.FontsCSS(canonical.Config)
@@ -43,6 +55,14 @@ func Build(canonical config.Canonical, result extract.Result) (Output, error) {
 	}
 
 	rules, matched, unknown := buildUtilities(canonical, result.Classes)
+	properties := strings.TrimRight(renderProperties(rules), "\n")
+	if properties != "" {
+		sections = append(sections, properties)
+	}
+	keyframes := strings.TrimRight(string(emit.KeyframesCSS(canonical.Config, usedKeyframes(rules))), "\n")
+	if keyframes != "" {
+		sections = append(sections, keyframes)
+	}
 	utilities := strings.TrimRight(renderRules(rules), "\n")
 	if utilities != "" {
 		sections = append(sections, utilities)
@@ -53,7 +73,7 @@ func Build(canonical config.Canonical, result extract.Result) (Output, error) {
 		css = strings.Join(sections, "\n\n") + "\n"
 	}
 
-	warnings := []string{}
+	warnings := append([]string{}, blockWarnings...)
 	if policy == "warn" {
 		for _, class := range unknown {
 			warnings = append(warnings, fmt.Sprintf("unknown class: %s", class))
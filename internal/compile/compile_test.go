@@ -0,0 +1,215 @@
+package compile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lcss/internal/config"
+	"lcss/internal/extract"
+)
+
+func buildCSS(t *testing.T, site string, tokens bool, classes ...string) string {
+	t.Helper()
+
+	sitePath := ""
+	if site != "" {
+		sitePath = filepath.Join(t.TempDir(), "site.json")
+		if err := os.WriteFile(sitePath, []byte(site), 0o644); err != nil {
+			t.Fatalf("write site config: %v", err)
+		}
+	}
+	cfg, err := config.Load("", sitePath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate config: %v", err)
+	}
+	base := false
+	cfg.Build.Emit = config.EmitOptions{TokensCSS: tokens, Base: &base}
+
+	output, err := Build(cfg.Canonicalize(), extract.Result{Classes: classes})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if len(output.Warnings) > 0 {
+		t.Fatalf("unexpected warnings: %v", output.Warnings)
+	}
+	return string(output.CSS)
+}
+
+func TestBuildUtilityFamilies(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []string
+		want    []string
+	}{
+		{
+			name:    "gradients",
+			classes: []string{"bg-linear-to-r", "from-blue-500", "via-violet-500", "via-40"},
+			want: []string{
+				".bg-linear-to-r {\n  background-image: linear-gradient(to right, var(--lc-gradient-from, transparent) var(--lc-gradient-from-position,), var(--lc-gradient-via,) var(--lc-gradient-to, transparent) var(--lc-gradient-to-position,));\n}\n",
+				".from-blue-500 {\n  --lc-gradient-from: var(--color-blue-500);\n}\n",
+				".via-violet-500 {\n  --lc-gradient-via: var(--color-violet-500) var(--lc-gradient-via-position,),;\n}\n",
+				".via-40 {\n  --lc-gradient-via-position: var(--gradient-stop-40);\n}\n",
+			},
+		},
+		{
+			name:    "filters compose",
+			classes: []string{"blur-sm", "backdrop-blur-md"},
+			want: []string{
+				".blur-sm {\n  --lc-blur: blur(var(--blur-sm));\n  filter: var(--lc-blur,) var(--lc-brightness,) var(--lc-contrast,) var(--lc-grayscale,) var(--lc-saturate,) var(--lc-drop-shadow,);\n}\n",
+				".backdrop-blur-md {\n  --lc-backdrop-blur: blur(var(--blur-md));\n  backdrop-filter: var(--lc-backdrop-blur,) var(--lc-backdrop-brightness,) var(--lc-backdrop-contrast,) var(--lc-backdrop-grayscale,) var(--lc-backdrop-saturate,);\n}\n",
+			},
+		},
+		{
+			name:    "ring and shadow compose",
+			classes: []string{"ring-2", "ring-blue-500", "ring-offset-2", "shadow-md"},
+			want: []string{
+				".ring-2 {\n  --lc-ring-offset-shadow: var(--lc-ring-inset,) 0 0 0 var(--lc-ring-offset-width, 0px) var(--lc-ring-offset-color, #fff);\n  --lc-ring-shadow: var(--lc-ring-inset,) 0 0 0 calc(var(--ring-2) + var(--lc-ring-offset-width, 0px)) var(--lc-ring-color, currentColor);\n  box-shadow: var(--lc-ring-offset-shadow, 0 0 #0000), var(--lc-ring-shadow, 0 0 #0000), var(--lc-shadow, 0 0 #0000);\n}\n",
+				".ring-blue-500 {\n  --lc-ring-color: var(--color-blue-500);\n}\n",
+				".ring-offset-2 {\n  --lc-ring-offset-width: var(--ring-2);\n}\n",
+				".shadow-md {\n  --lc-shadow: var(--shadow-md);\n  box-shadow: var(--lc-ring-offset-shadow, 0 0 #0000), var(--lc-ring-shadow, 0 0 #0000), var(--lc-shadow, 0 0 #0000);\n}\n",
+			},
+		},
+		{
+			name:    "property registration",
+			classes: []string{"ring-blue-500"},
+			want: []string{
+				"@property --lc-ring-color {\n  syntax: \"*\";\n  inherits: false;\n}\n",
+			},
+		},
+		{
+			name:    "keyframes and reduced motion",
+			classes: []string{"animate-spin", "md:animate-pulse"},
+			want: []string{
+				"@keyframes spin {\n  to {\n    transform: rotate(360deg);\n  }\n}\n",
+				"@keyframes pulse {\n  50% {\n    opacity: var(--opacity-50);\n  }\n}\n",
+				".animate-spin {\n  animation: var(--animation-spin);\n}\n@media (prefers-reduced-motion: reduce) {\n  .animate-spin {\n    animation: none;\n  }\n}\n",
+				"@media (min-width: 768px) and (prefers-reduced-motion: reduce) {\n  .md\\:animate-pulse {\n    animation: none;\n  }\n}\n",
+			},
+		},
+		{
+			name:    "space and divide target siblings",
+			classes: []string{"space-x-4", "hover:space-y-2", "divide-y", "md:divide-x-2"},
+			want: []string{
+				".space-x-4 > :not(:last-child) {\n  margin-inline-end: var(--space-4);\n}\n",
+				".hover\\:space-y-2:hover > :not(:last-child) {\n  margin-block-end: var(--space-2);\n}\n",
+				".divide-y > :not(:last-child) {\n  border-block-end-width: 1px;\n  border-block-end-style: solid;\n}\n",
+				"@media (min-width: 768px) {\n  .md\\:divide-x-2 > :not(:last-child) {\n    border-inline-end-width: var(--border-width-2);\n    border-inline-end-style: solid;\n  }\n}\n",
+			},
+		},
+		{
+			name:    "prose where selectors",
+			classes: []string{"prose", "prose-lg"},
+			want: []string{
+				".prose {\n  font-size: var(--font-size-base);\n  line-height: var(--line-height-relaxed);\n  color: var(--color-ink-100);\n}\n",
+				".prose :where(a) {\n  text-decoration: underline;\n  font-weight: 500;\n  color: var(--color-blue-300);\n}\n",
+				".prose :where(li)::marker {\n  color: var(--color-ink-400);\n}\n",
+				".prose-lg :where(h1) {\n  font-size: var(--font-size-5xl);\n}\n",
+			},
+		},
+		{
+			name:    "text flow",
+			classes: []string{"line-clamp-3", "text-balance", "hyphens-auto"},
+			want: []string{
+				".line-clamp-3 {\n  overflow: hidden;\n  display: -webkit-box;\n  -webkit-box-orient: vertical;\n  -webkit-line-clamp: 3;\n}\n",
+				".text-balance {\n  text-wrap: balance;\n}\n",
+				".hyphens-auto {\n  hyphens: auto;\n}\n",
+			},
+		},
+		{
+			name:    "scroll snap",
+			classes: []string{"snap-x", "snap-mandatory", "snap-start", "scroll-px-4"},
+			want: []string{
+				".snap-x {\n  scroll-snap-type: x var(--lc-scroll-snap-strictness, proximity);\n}\n",
+				".snap-mandatory {\n  --lc-scroll-snap-strictness: mandatory;\n}\n",
+				".snap-start {\n  scroll-snap-align: start;\n}\n",
+				".scroll-px-4 {\n  scroll-padding-left: var(--space-4);\n  scroll-padding-right: var(--space-4);\n}\n",
+			},
+		},
+		{
+			name:    "svg and form control colors",
+			classes: []string{"fill-blue-500", "stroke-width-2", "accent-blue-500", "caret-auto", "placeholder-ink-400", "hover:placeholder-ink-400"},
+			want: []string{
+				".fill-blue-500 {\n  fill: var(--color-blue-500);\n}\n",
+				".stroke-width-2 {\n  stroke-width: var(--border-width-2);\n}\n",
+				".accent-blue-500 {\n  accent-color: var(--color-blue-500);\n}\n",
+				".caret-auto {\n  caret-color: auto;\n}\n",
+				".placeholder-ink-400::placeholder {\n  color: var(--color-ink-400);\n}\n",
+				".hover\\:placeholder-ink-400:hover::placeholder {\n  color: var(--color-ink-400);\n}\n",
+			},
+		},
+		{
+			name:    "grid templates",
+			classes: []string{"grid-areas-app", "area-header", "grid-cols-fill-md"},
+			want: []string{
+				".grid-areas-app {\n  grid-template-areas: \"header header\" \"sidebar main\" \"footer footer\";\n}\n",
+				".area-header {\n  grid-area: header;\n}\n",
+				".grid-cols-fill-md {\n  grid-template-columns: repeat(auto-fill, minmax(var(--size-md), 1fr));\n}\n",
+			},
+		},
+		{
+			name:    "tables",
+			classes: []string{"table-fixed", "border-spacing-2"},
+			want: []string{
+				".table-fixed {\n  table-layout: fixed;\n}\n",
+				".border-spacing-2 {\n  --lc-border-spacing-x: var(--space-2);\n  --lc-border-spacing-y: var(--space-2);\n  border-spacing: var(--lc-border-spacing-x) var(--lc-border-spacing-y);\n}\n",
+			},
+		},
+		{
+			name:    "font features compose",
+			classes: []string{"tabular-nums", "slashed-zero"},
+			want: []string{
+				".tabular-nums {\n  --lc-numeric-spacing: tabular-nums;\n  font-variant-numeric: var(--lc-ordinal,) var(--lc-slashed-zero,) var(--lc-numeric-figure,) var(--lc-numeric-spacing,) var(--lc-numeric-fraction,);\n}\n",
+				".slashed-zero {\n  --lc-slashed-zero: slashed-zero;\n  font-variant-numeric: var(--lc-ordinal,) var(--lc-slashed-zero,) var(--lc-numeric-figure,) var(--lc-numeric-spacing,) var(--lc-numeric-fraction,);\n}\n",
+			},
+		},
+		{
+			name:    "blend and touch",
+			classes: []string{"mix-blend-multiply", "touch-pan-x", "touch-pinch-zoom"},
+			want: []string{
+				".mix-blend-multiply {\n  mix-blend-mode: multiply;\n}\n",
+				".touch-pan-x {\n  --lc-pan-x: pan-x;\n  touch-action: var(--lc-pan-x,) var(--lc-pan-y,) var(--lc-pinch-zoom,);\n}\n",
+				".touch-pinch-zoom {\n  --lc-pinch-zoom: pinch-zoom;\n  touch-action: var(--lc-pan-x,) var(--lc-pan-y,) var(--lc-pinch-zoom,);\n}\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			css := buildCSS(t, "", false, tt.classes...)
+			for _, want := range tt.want {
+				if !strings.Contains(css, want) {
+					t.Errorf("output missing:\n%s\ngot:\n%s", want, css)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildScaleTokens(t *testing.T) {
+	site := `{
+  "scales": {
+    "fontSize": {"hero": {"min": "2rem", "max": "4rem"}},
+    "space": {"generate": {"base": "0.5rem", "steps": [1, 3]}}
+  }
+}`
+	css := buildCSS(t, site, true, "text-hero", "p-3")
+
+	want := []string{
+		"  --font-size-hero: clamp(2rem, 0rem + 5vw, 4rem);\n",
+		"  --space-1: 0.5rem;\n",
+		"  --space-3: 1.5rem;\n",
+		"  --space-4: 1rem;\n",
+		".text-hero {\n  font-size: var(--font-size-hero);\n}\n",
+		".p-3 {\n  padding: var(--space-3);\n}\n",
+	}
+	for _, line := range want {
+		if !strings.Contains(css, line) {
+			t.Errorf("output missing:\n%s", line)
+		}
+	}
+}
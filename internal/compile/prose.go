@@ -0,0 +1,175 @@
+package compile
+
+import (
+	"fmt"
+	"strings"
+
+	"lcss/internal/config"
+)
+
+type proseRule struct {
+	Element string
+	Decls   []Decl
+}
+
+func matchProse(base string, canonical config.Canonical) ([]proseRule, bool) {
+	prose := canonical.Config.Prose
+	if base == "prose" {
+		if len(prose.Sizes) == 0 && len(prose.Colors) == 0 {
+			return nil, false
+		}
+		rules := proseStructure(canonical)
+		if size, ok := prose.Sizes["default"]; ok {
+			rules = append(rules, proseSize(size)...)
+		}
+		if color, ok := prose.Colors["default"]; ok {
+			rules = append(rules, proseColor(color)...)
+		}
+		return mergeProseRules(rules), true
+	}
+	if !strings.HasPrefix(base, "prose-") {
+		return nil, false
+	}
+	name := strings.TrimPrefix(base, "prose-")
+	if name == "default" {
+		return nil, false
+	}
+	if size, ok := prose.Sizes[name]; ok {
+		return mergeProseRules(proseSize(size)), true
+	}
+	if color, ok := prose.Colors[name]; ok {
+		return mergeProseRules(proseColor(color)), true
+	}
+	return nil, false
+}
+
+func proseStructure(canonical config.Canonical) []proseRule {
+	mono := "monospace"
+	if _, ok := canonical.Tokens.Themes["default"].Fonts["mono"]; ok {
+		mono = "var(--font-mono)"
+	}
+	radius := "0.375rem"
+	if key := defaultRadiusKey(canonical.Tokens.Scales["radius"]); key != "" {
+		radius = fmt.Sprintf("var(--radius-%s)", key)
+	}
+	return []proseRule{
+		{Element: "h1, h2, h3, h4", Decls: []Decl{{Property: "font-weight", Value: "700"}}},
+		{Element: "a", Decls: []Decl{
+			{Property: "text-decoration", Value: "underline"},
+			{Property: "font-weight", Value: "500"},
+		}},
+		{Element: "strong", Decls: []Decl{{Property: "font-weight", Value: "600"}}},
+		{Element: "code", Decls: []Decl{
+			{Property: "font-family", Value: mono},
+			{Property: "font-size", Value: "0.875em"},
+		}},
+		{Element: "pre", Decls: []Decl{
+			{Property: "overflow-x", Value: "auto"},
+			{Property: "border-radius", Value: radius},
+		}},
+		{Element: "pre code", Decls: []Decl{
+			{Property: "font-size", Value: "inherit"},
+			{Property: "color", Value: "inherit"},
+		}},
+		{Element: "ul", Decls: []Decl{{Property: "list-style", Value: "disc"}}},
+		{Element: "ol", Decls: []Decl{{Property: "list-style", Value: "decimal"}}},
+		{Element: "blockquote", Decls: []Decl{
+			{Property: "font-style", Value: "italic"},
+			{Property: "border-left-width", Value: "0.25rem"},
+			{Property: "border-left-style", Value: "solid"},
+		}},
+		{Element: "hr", Decls: []Decl{
+			{Property: "border", Value: "0"},
+			{Property: "border-top-width", Value: "1px"},
+			{Property: "border-top-style", Value: "solid"},
+		}},
+		{Element: "img, video", Decls: []Decl{
+			{Property: "max-width", Value: "100%"},
+			{Property: "height", Value: "auto"},
+		}},
+		{Element: "table", Decls: []Decl{
+			{Property: "width", Value: "100%"},
+			{Property: "border-collapse", Value: "collapse"},
+		}},
+	}
+}
+
+func proseSize(size config.ProseSize) []proseRule {
+	var rules []proseRule
+	add := func(element, property, value string) {
+		rules = append(rules, proseRule{Element: element, Decls: []Decl{{Property: property, Value: value}}})
+	}
+	if size.FontSize != "" {
+		add("", "font-size", fmt.Sprintf("var(--font-size-%s)", size.FontSize))
+	}
+	if size.LineHeight != "" {
+		add("", "line-height", fmt.Sprintf("var(--line-height-%s)", size.LineHeight))
+	}
+	if size.Spacing != "" {
+		spacing := fmt.Sprintf("var(--space-%s)", size.Spacing)
+		add("p, ul, ol, blockquote, pre, figure, table, hr", "margin-top", "0")
+		add("p, ul, ol, blockquote, pre, figure, table, hr", "margin-bottom", spacing)
+		add("h1, h2, h3, h4", "margin-top", fmt.Sprintf("calc(%s * 2)", spacing))
+		add("h1, h2, h3, h4", "margin-bottom", spacing)
+		add("pre", "padding", spacing)
+	}
+	if size.Indent != "" {
+		indent := fmt.Sprintf("var(--space-%s)", size.Indent)
+		add("ul, ol", "padding-left", indent)
+		add("blockquote", "padding-left", indent)
+	}
+	for _, heading := range []struct{ element, key string }{{"h1", size.H1}, {"h2", size.H2}, {"h3", size.H3}, {"h4", size.H4}} {
+		if heading.key != "" {
+			add(heading.element, "font-size", fmt.Sprintf("var(--font-size-%s)", heading.key))
+		}
+	}
+	if size.HeadingLineHeight != "" {
+		add("h1, h2, h3, h4", "line-height", fmt.Sprintf("var(--line-height-%s)", size.HeadingLineHeight))
+	}
+	return rules
+}
+
+func proseColor(color config.ProseColor) []proseRule {
+	var rules []proseRule
+	add := func(element, property, key string) {
+		if key == "" {
+			return
+		}
+		rules = append(rules, proseRule{Element: element, Decls: []Decl{{Property: property, Value: fmt.Sprintf("var(--color-%s)", key)}}})
+	}
+	add("", "color", color.Body)
+	add("h1, h2, h3, h4, strong", "color", color.Headings)
+	add("a", "color", color.Links)
+	add("code", "color", color.Code)
+	add("pre", "background-color", color.Pre)
+	add("blockquote", "color", color.Quotes)
+	add("blockquote", "border-left-color", color.Borders)
+	add("hr", "border-top-color", color.Borders)
+	add("li::marker", "color", color.Bullets)
+	return rules
+}
+
+func mergeProseRules(rules []proseRule) []proseRule {
+	merged := make([]proseRule, 0, len(rules))
+	index := map[string]int{}
+	for _, rule := range rules {
+		if i, ok := index[rule.Element]; ok {
+			merged[i].Decls = append(merged[i].Decls, rule.Decls...)
+			continue
+		}
+		index[rule.Element] = len(merged)
+		merged = append(merged, proseRule{Element: rule.Element, Decls: append([]Decl(nil), rule.Decls...)})
+	}
+	return merged
+}
+
+func proseSelector(selector, element string) string {
+	if element == "" {
+		return selector
+	}
+	pseudo := ""
+	if i := strings.Index(element, "::"); i >= 0 {
+		element, pseudo = element[:i], element[i:]
+	}
+	return selector + " :where(" + element + ")" + pseudo
+}
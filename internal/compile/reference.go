@@ -10,6 +10,7 @@ import (
 )
 
 const defaultGridSize = 12
+const defaultLineClamp = 6
 
 func AllClasses(canonical config.Canonical) []string {
 	base := baseClasses(canonical)
@@ -121,9 +122,15 @@ func baseClasses(canonical config.Canonical) []string {
 	translateKeys := mapKeys(canonical.Tokens.Scales["translate"])
 	rotateKeys := mapKeys(canonical.Tokens.Scales["rotate"])
 	scaleKeys := mapKeys(canonical.Tokens.Scales["scale"])
+	gradientStopKeys := mapKeys(canonical.Tokens.Scales["gradientStop"])
+	blurKeys := mapKeys(canonical.Tokens.Scales["blur"])
+	brightnessKeys := mapKeys(canonical.Tokens.Scales["brightness"])
+	dropShadowKeys := mapKeys(canonical.Tokens.Scales["dropShadow"])
+	ringKeys := mapKeys(canonical.Tokens.Scales["ring"])
+	animationKeys := mapKeys(canonical.Tokens.Scales["animation"])
 
 	colors := canonical.Tokens.Themes["default"].Colors
-	colorKeys := mapKeys(colors)
+	colorKeys := mergeKeys(mapKeys(colors), []string{"current", "transparent", "inherit"})
 	fonts := canonical.Tokens.Themes["default"].Fonts
 	fontKeys := mapKeys(fonts)
 
@@ -132,6 +139,20 @@ func baseClasses(canonical config.Canonical) []string {
 		addAll(prefix, spaceKeys)
 	}
 
+	for _, prefix := range []string{"p-", "px-", "py-", "pt-", "pr-", "pb-", "pl-", "m-", "mx-", "my-", "mt-", "mr-", "mb-", "ml-"} {
+		addAll("scroll-"+prefix, spaceKeys)
+	}
+	for _, value := range []string{"scroll-auto", "scroll-smooth", "snap-none", "snap-x", "snap-y", "snap-both", "snap-mandatory", "snap-proximity", "snap-start", "snap-end", "snap-center", "snap-align-none", "snap-normal", "snap-always"} {
+		add(value)
+	}
+	for _, axis := range []string{"", "x-", "y-"} {
+		for _, value := range []string{"auto", "contain", "none"} {
+			add("overscroll-" + axis + value)
+		}
+	}
+	addAll("space-x-", spaceKeys)
+	addAll("space-y-", spaceKeys)
+
 	commonSizeKeys := mergeKeys(sizeValueKeys(spaceKeys, sizeKeys))
 	addAll("w-", commonSizeKeys)
 	addAll("h-", commonSizeKeys)
@@ -147,9 +168,18 @@ func baseClasses(canonical config.Canonical) []string {
 		add("container")
 	}
 
-	for _, value := range []string{"block", "inline-block", "inline", "flex", "inline-flex", "grid", "hidden", "contents"} {
+	for _, value := range []string{"block", "inline-block", "inline", "flex", "inline-flex", "grid", "inline-grid", "hidden", "contents", "flow-root", "list-item", "table", "inline-table", "table-caption", "table-cell", "table-column", "table-column-group", "table-footer-group", "table-header-group", "table-row-group", "table-row", "box-border", "box-content"} {
+		add(value)
+	}
+	for _, value := range []string{"float-left", "float-right", "float-start", "float-end", "float-none", "clear-left", "clear-right", "clear-start", "clear-end", "clear-both", "clear-none"} {
+		add(value)
+	}
+	for _, value := range []string{"border-collapse", "border-separate", "table-auto", "table-fixed", "caption-top", "caption-bottom"} {
 		add(value)
 	}
+	for _, prefix := range []string{"border-spacing-", "border-spacing-x-", "border-spacing-y-"} {
+		addAll(prefix, spaceKeys)
+	}
 
 	for _, value := range []string{"static", "relative", "absolute", "fixed", "sticky"} {
 		add(value)
@@ -162,6 +192,19 @@ func baseClasses(canonical config.Canonical) []string {
 	for _, value := range []string{"flex-row", "flex-col", "flex-wrap", "flex-nowrap", "flex-wrap-reverse", "flex-1", "flex-auto", "flex-initial", "flex-none", "grow", "grow-0", "shrink", "shrink-0"} {
 		add(value)
 	}
+	add("order-first")
+	add("order-last")
+	add("order-none")
+	addAll("basis-", sizeValueKeys(spaceKeys, sizeKeys))
+	add("columns-auto")
+	addAll("columns-", sizeKeys)
+	for _, value := range []string{"auto", "avoid", "avoid-page", "avoid-column"} {
+		add("break-inside-" + value)
+	}
+	for _, value := range []string{"auto", "avoid", "all", "avoid-page", "page", "left", "right", "column"} {
+		add("break-before-" + value)
+		add("break-after-" + value)
+	}
 	for _, value := range []string{"start", "center", "end", "stretch", "baseline"} {
 		add("items-" + value)
 		add("self-" + value)
@@ -189,7 +232,30 @@ func baseClasses(canonical config.Canonical) []string {
 		add("col-end-" + value)
 		add("row-start-" + value)
 		add("row-end-" + value)
+		add("order-" + value)
+		add("columns-" + value)
 	}
+	for _, value := range []string{"grid-cols-subgrid", "grid-rows-subgrid", "grid-areas-none", "area-auto", "grid-flow-row", "grid-flow-col", "grid-flow-dense", "grid-flow-row-dense", "grid-flow-col-dense"} {
+		add(value)
+	}
+	for _, value := range []string{"auto", "min", "max", "fr"} {
+		add("auto-cols-" + value)
+		add("auto-rows-" + value)
+	}
+	addAll("grid-cols-fill-", sizeKeys)
+	addAll("grid-cols-fit-", sizeKeys)
+	for name, template := range canonical.Config.Grid.Templates {
+		if template.Columns != "" {
+			add("grid-cols-" + name)
+		}
+		if template.Rows != "" {
+			add("grid-rows-" + name)
+		}
+		if len(template.Areas) > 0 {
+			add("grid-areas-" + name)
+		}
+	}
+	addAll("area-", canonical.Config.Grid.AreaNames())
 	for _, value := range []string{"start", "center", "end", "stretch", "baseline"} {
 		add("place-items-" + value)
 	}
@@ -210,14 +276,56 @@ func baseClasses(canonical config.Canonical) []string {
 	}
 	addAll("tracking-", letterSpacingKeys)
 
+	for _, value := range []string{"truncate", "text-ellipsis", "text-clip", "text-wrap", "text-nowrap", "text-balance", "text-pretty", "whitespace-normal", "whitespace-nowrap", "whitespace-pre", "whitespace-pre-line", "whitespace-pre-wrap", "whitespace-break-spaces", "break-normal", "break-words", "break-all", "break-keep", "hyphens-none", "hyphens-manual", "hyphens-auto", "line-clamp-none"} {
+		add(value)
+	}
+	lineClamp := canonical.Config.Build.LineClamp
+	if lineClamp == 0 {
+		lineClamp = defaultLineClamp
+	}
+	for i := 1; i <= lineClamp; i++ {
+		add("line-clamp-" + fmtInt(i))
+	}
+
+	for _, value := range []string{"normal-nums", "ordinal", "slashed-zero", "lining-nums", "oldstyle-nums", "proportional-nums", "tabular-nums", "diagonal-fractions", "stacked-fractions", "antialiased", "subpixel-antialiased"} {
+		add(value)
+	}
+	for _, value := range []string{"solid", "double", "dotted", "dashed", "wavy", "auto", "from-font"} {
+		add("decoration-" + value)
+	}
+	addAll("decoration-", colorKeys)
+	addAll("decoration-", mergeKeys(borderWidthKeys, []string{"0", "2", "4", "8"}))
+	add("underline-offset-auto")
+	addAll("underline-offset-", mergeKeys(borderWidthKeys, []string{"0", "2", "4", "8"}))
+
 	addAll("bg-", colorKeys)
 	addAll("text-", colorKeys)
 	addAll("border-", colorKeys)
 
+	add("fill-none")
+	add("stroke-none")
+	add("accent-auto")
+	add("caret-auto")
+	for _, prefix := range []string{"fill-", "stroke-", "accent-", "caret-", "placeholder-"} {
+		addAll(prefix, colorKeys)
+	}
+	addAll("stroke-width-", mergeKeys(borderWidthKeys, []string{"0", "2", "4", "8"}))
+
 	for _, value := range []string{"bg-cover", "bg-contain", "bg-center", "bg-top", "bg-right", "bg-bottom", "bg-left", "bg-fixed", "bg-local", "bg-scroll", "bg-repeat", "bg-no-repeat", "bg-repeat-x", "bg-repeat-y"} {
 		add(value)
 	}
 
+	add("bg-none")
+	add("bg-radial")
+	add("bg-conic")
+	for _, value := range []string{"t", "tr", "r", "br", "b", "bl", "l", "tl"} {
+		add("bg-linear-to-" + value)
+	}
+	for _, prefix := range []string{"from-", "via-", "to-"} {
+		addAll(prefix, colorKeys)
+		addAll(prefix, gradientStopKeys)
+	}
+
 	add("border")
 	for _, value := range []string{"border-solid", "border-dashed", "border-dotted", "border-double", "border-none"} {
 		add(value)
@@ -228,6 +336,19 @@ func baseClasses(canonical config.Canonical) []string {
 		addAll(prefix, borderWidthAll)
 	}
 
+	for _, value := range []string{"outline", "outline-none", "outline-solid", "outline-dashed", "outline-dotted", "outline-double"} {
+		add(value)
+	}
+	addAll("outline-", borderWidthAll)
+	addAll("outline-", colorKeys)
+	addAll("outline-offset-", borderWidthAll)
+
+	add("divide-x")
+	add("divide-y")
+	addAll("divide-x-", borderWidthAll)
+	addAll("divide-y-", borderWidthAll)
+	addAll("divide-", colorKeys)
+
 	add("rounded")
 	addAll("rounded-", radiusKeys)
 	for _, value := range []string{"t", "b", "l", "r", "tl", "tr", "bl", "br"} {
@@ -238,7 +359,36 @@ func baseClasses(canonical config.Canonical) []string {
 	add("shadow-none")
 	addAll("shadow-", shadowKeys)
 
+	if len(ringKeys) > 0 {
+		add("ring")
+	}
+	add("ring-inset")
+	addAll("ring-", ringKeys)
+	addAll("ring-", colorKeys)
+	addAll("ring-offset-", ringKeys)
+	addAll("ring-offset-", colorKeys)
+
 	addAll("opacity-", opacityKeys)
+
+	add("filter-none")
+	add("backdrop-filter-none")
+	for _, prefix := range []string{"", "backdrop-"} {
+		if len(blurKeys) > 0 {
+			add(prefix + "blur")
+		}
+		addAll(prefix+"blur-", blurKeys)
+		addAll(prefix+"brightness-", brightnessKeys)
+		addAll(prefix+"contrast-", brightnessKeys)
+		addAll(prefix+"saturate-", brightnessKeys)
+		add(prefix + "grayscale")
+		add(prefix + "grayscale-0")
+	}
+	if len(dropShadowKeys) > 0 {
+		add("drop-shadow")
+	}
+	add("drop-shadow-none")
+	addAll("drop-shadow-", dropShadowKeys)
+
 	add("z-auto")
 	addAll("z-", zIndexKeys)
 
@@ -268,10 +418,38 @@ func baseClasses(canonical config.Canonical) []string {
 	addAll("rotate-", rotateKeys)
 	addAll("scale-", scaleKeys)
 
-	for _, value := range []string{"cursor-pointer", "cursor-default", "cursor-text", "cursor-not-allowed", "pointer-events-none", "pointer-events-auto", "select-none", "select-text", "select-all", "select-auto", "isolate", "isolation-auto"} {
+	add("animate-none")
+	addAll("animate-", animationKeys)
+
+	addAll("cursor-", cursorValues)
+	addAll("mix-blend-", blendModes)
+	add("mix-blend-plus-lighter")
+	addAll("bg-blend-", blendModes)
+	for _, value := range []string{"auto", "none", "manipulation", "pan-x", "pan-left", "pan-right", "pan-y", "pan-up", "pan-down", "pinch-zoom"} {
+		add("touch-" + value)
+	}
+	for _, value := range []string{"appearance-none", "appearance-auto", "resize", "resize-none", "resize-x", "resize-y", "will-change-auto", "will-change-scroll", "will-change-contents", "will-change-transform", "will-change-opacity", "content-visibility-visible", "content-visibility-auto", "content-visibility-hidden"} {
+		add(value)
+	}
+	for _, value := range []string{"pointer-events-none", "pointer-events-auto", "select-none", "select-text", "select-all", "select-auto", "isolate", "isolation-auto"} {
 		add(value)
 	}
 
+	prose := canonical.Config.Prose
+	if len(prose.Sizes) > 0 || len(prose.Colors) > 0 {
+		add("prose")
+	}
+	for name := range prose.Sizes {
+		if name != "default" {
+			add("prose-" + name)
+		}
+	}
+	for name := range prose.Colors {
+		if name != "default" {
+			add("prose-" + name)
+		}
+	}
+
 	classes := make([]string, 0, len(set))
 	for class := range set {
 		classes = append(classes, class)
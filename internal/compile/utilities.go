@@ -2,10 +2,12 @@ package compile
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
 	"lcss/internal/config"
+	"lcss/internal/emit"
 	"lcss/internal/extract"
 )
 
@@ -15,11 +17,17 @@ type Decl struct {
 }
 
 type Rule struct {
-	Selector string
-	Decls    []Decl
-	Media    string
+	Selector  string
+	Suffix    string
+	Decls     []Decl
+	Media     string
+	Keyframes []string
 }
 
+var composedVarPattern = regexp.MustCompile(`--lc-[a-z0-9-]+`)
+
+const siblingSuffix = " > :not(:last-child)"
+
 type variantConfig struct {
 	separator   string
 	classPrefix string
@@ -35,12 +43,12 @@ func buildUtilities(canonical config.Canonical, classes []string) ([]Rule, []str
 	unknown := make([]string, 0)
 
 	for _, class := range classes {
-		rule, ok := matchClass(canonical, variants, class)
+		classRules, ok := matchClass(canonical, variants, class)
 		if !ok {
 			unknown = append(unknown, class)
 			continue
 		}
-		rules = append(rules, rule)
+		rules = append(rules, classRules...)
 		matched = append(matched, class)
 	}
 
@@ -72,15 +80,10 @@ func buildVariantConfig(cfg config.Config) variantConfig {
 	}
 }
 
-func matchClass(canonical config.Canonical, variants variantConfig, class string) (Rule, bool) {
+func matchClass(canonical config.Canonical, variants variantConfig, class string) ([]Rule, bool) {
 	parsed, ok := parseClass(variants, class)
 	if !ok {
-		return Rule{}, false
-	}
-
-	decls, ok := matchUtility(parsed.Base, canonical)
-	if !ok {
-		return Rule{}, false
+		return nil, false
 	}
 
 	selector := "." + escapeClass(class)
@@ -88,11 +91,45 @@ func matchClass(canonical config.Canonical, variants variantConfig, class string
 		selector += strings.Join(parsed.Pseudos, "")
 	}
 
-	return Rule{
-		Selector: selector,
-		Decls:    decls,
-		Media:    parsed.Media,
-	}, true
+	if components, ok := matchProse(parsed.Base, canonical); ok {
+		rules := make([]Rule, 0, len(components))
+		for _, component := range components {
+			rules = append(rules, Rule{
+				Selector: proseSelector(selector, component.Element),
+				Decls:    component.Decls,
+				Media:    parsed.Media,
+			})
+		}
+		return rules, true
+	}
+
+	rule, ok := matchUtility(parsed.Base, canonical)
+	if !ok {
+		return nil, false
+	}
+	rule.Selector = selector
+	rule.Media = parsed.Media
+
+	rules := []Rule{rule}
+	if reduced, ok := matchReducedMotion(parsed.Base, canonical.Tokens.Scales["animation"]); ok {
+		rules = append(rules, Rule{
+			Selector: selector,
+			Suffix:   rule.Suffix,
+			Decls:    reduced,
+			Media:    joinMedia(parsed.Media, reducedMotionMedia),
+		})
+	}
+	return rules, true
+}
+
+func joinMedia(queries ...string) string {
+	parts := make([]string, 0, len(queries))
+	for _, query := range queries {
+		if query != "" {
+			parts = append(parts, query)
+		}
+	}
+	return strings.Join(parts, " and ")
 }
 
 type parsedClass struct {
@@ -146,7 +183,7 @@ func parseClass(variants variantConfig, class string) (parsedClass, bool) {
 	}, true
 }
 
-func matchUtility(base string, canonical config.Canonical) ([]Decl, bool) {
+func matchUtility(base string, canonical config.Canonical) (Rule, bool) {
 	space := canonical.Tokens.Scales["space"]
 	size := canonical.Tokens.Scales["size"]
 	maxWidth := canonical.Tokens.Scales["maxWidth"]
@@ -170,75 +207,135 @@ func matchUtility(base string, canonical config.Canonical) ([]Decl, bool) {
 	rotate := canonical.Tokens.Scales["rotate"]
 	scale := canonical.Tokens.Scales["scale"]
 	container := canonical.Tokens.Scales["container"]
+	gradientStop := canonical.Tokens.Scales["gradientStop"]
+	blur := canonical.Tokens.Scales["blur"]
+	brightness := canonical.Tokens.Scales["brightness"]
+	dropShadow := canonical.Tokens.Scales["dropShadow"]
+	ring := canonical.Tokens.Scales["ring"]
+	animation := canonical.Tokens.Scales["animation"]
 
 	if decls, ok := matchSpacing(base, space); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
+	}
+	if rule, ok := matchSiblingSpacing(base, space); ok {
+		return rule, true
+	}
+	if decls, ok := matchScroll(base, space); ok {
+		return Rule{Decls: decls}, true
+	}
+	if rule, ok := matchDivide(base, colors, borderWidth); ok {
+		return rule, true
 	}
 	if decls, ok := matchSizing(base, space, size, maxWidth, maxHeight, container); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchDisplay(base); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchPosition(base, space, size); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchFlex(base); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
-	if decls, ok := matchGrid(base); ok {
-		return decls, true
+	if decls, ok := matchFlexItem(base, space, size); ok {
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchColumns(base, size); ok {
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchFloat(base); ok {
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchTable(base, space); ok {
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchGrid(base, size, canonical.Config.Grid); ok {
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchTypography(base, fonts, fontSize, lineHeight, fontWeight, colors); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchTypographyExtras(base, letterSpacing); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchTextFlow(base, canonical.Config.Build.LineClamp); ok {
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchFontFeatures(base); ok {
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchDecoration(base, colors, borderWidth); ok {
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchColors(base, colors); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchControlColors(base, colors, borderWidth); ok {
+		return Rule{Decls: decls}, true
+	}
+	if rule, ok := matchPlaceholder(base, colors); ok {
+		return rule, true
 	}
 	if decls, ok := matchBackground(base); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchGradient(base, colors, gradientStop); ok {
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchBorders(base, colors, borderWidth); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchOutline(base, colors, borderWidth); ok {
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchRadius(base, radius); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchShadow(base, shadow); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchRing(base, colors, ring); ok {
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchOpacity(base, opacity); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchFilter(base, blur, brightness, dropShadow); ok {
+		return Rule{Decls: decls}, true
+	}
+	if decls, ok := matchBlend(base); ok {
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchZIndex(base, zIndex); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchOverflow(base); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchVisibility(base); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchObject(base); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchAspect(base, aspect); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchTransition(base, duration, easing, delay); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 	if decls, ok := matchTransform(base, translate, rotate, scale, space); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
+	}
+	if rule, ok := matchAnimation(base, animation, canonical.Config.Keyframes); ok {
+		return rule, true
 	}
 	if decls, ok := matchInteraction(base); ok {
-		return decls, true
+		return Rule{Decls: decls}, true
 	}
 
-	return nil, false
+	return Rule{}, false
 }
 
 func matchSpacing(base string, space map[string]string) ([]Decl, bool) {
@@ -302,6 +399,108 @@ func parseSpacing(base string) (string, []string) {
 	}
 }
 
+func matchSiblingSpacing(base string, space map[string]string) (Rule, bool) {
+	property := ""
+	key := ""
+	switch {
+	case strings.HasPrefix(base, "space-x-"):
+		property, key = "margin-inline-end", strings.TrimPrefix(base, "space-x-")
+	case strings.HasPrefix(base, "space-y-"):
+		property, key = "margin-block-end", strings.TrimPrefix(base, "space-y-")
+	default:
+		return Rule{}, false
+	}
+	if _, ok := space[key]; !ok {
+		return Rule{}, false
+	}
+	return Rule{
+		Suffix: siblingSuffix,
+		Decls:  []Decl{{Property: property, Value: fmt.Sprintf("var(--space-%s)", key)}},
+	}, true
+}
+
+func matchScroll(base string, space map[string]string) ([]Decl, bool) {
+	switch base {
+	case "scroll-auto", "scroll-smooth":
+		return []Decl{{Property: "scroll-behavior", Value: strings.TrimPrefix(base, "scroll-")}}, true
+	case "snap-none":
+		return []Decl{{Property: "scroll-snap-type", Value: "none"}}, true
+	case "snap-x", "snap-y", "snap-both":
+		return []Decl{{Property: "scroll-snap-type", Value: strings.TrimPrefix(base, "snap-") + " var(--lc-scroll-snap-strictness, proximity)"}}, true
+	case "snap-mandatory", "snap-proximity":
+		return []Decl{{Property: "--lc-scroll-snap-strictness", Value: strings.TrimPrefix(base, "snap-")}}, true
+	case "snap-start", "snap-end", "snap-center":
+		return []Decl{{Property: "scroll-snap-align", Value: strings.TrimPrefix(base, "snap-")}}, true
+	case "snap-align-none":
+		return []Decl{{Property: "scroll-snap-align", Value: "none"}}, true
+	case "snap-normal", "snap-always":
+		return []Decl{{Property: "scroll-snap-stop", Value: strings.TrimPrefix(base, "snap-")}}, true
+	}
+	if strings.HasPrefix(base, "overscroll-") {
+		value := strings.TrimPrefix(base, "overscroll-")
+		property := "overscroll-behavior"
+		if strings.HasPrefix(value, "x-") || strings.HasPrefix(value, "y-") {
+			property += "-" + value[:1]
+			value = value[2:]
+		}
+		switch value {
+		case "auto", "contain", "none":
+			return []Decl{{Property: property, Value: value}}, true
+		}
+		return nil, false
+	}
+	if !strings.HasPrefix(base, "scroll-") {
+		return nil, false
+	}
+	key, props := parseSpacing(strings.TrimPrefix(base, "scroll-"))
+	if key == "" || len(props) == 0 || !strings.HasPrefix(props[0], "margin") && !strings.HasPrefix(props[0], "padding") {
+		return nil, false
+	}
+	if _, ok := space[key]; !ok {
+		return nil, false
+	}
+	value := fmt.Sprintf("var(--space-%s)", key)
+	decls := make([]Decl, 0, len(props))
+	for _, prop := range props {
+		decls = append(decls, Decl{Property: "scroll-" + prop, Value: value})
+	}
+	return decls, true
+}
+
+func matchDivide(base string, colors, borderWidth map[string]string) (Rule, bool) {
+	if !strings.HasPrefix(base, "divide-") {
+		return Rule{}, false
+	}
+	key := strings.TrimPrefix(base, "divide-")
+	if color, ok := colorValue(key, colors); ok {
+		return Rule{Suffix: siblingSuffix, Decls: []Decl{{Property: "border-color", Value: color}}}, true
+	}
+	side := ""
+	switch {
+	case key == "x" || strings.HasPrefix(key, "x-"):
+		side = "inline-end"
+	case key == "y" || strings.HasPrefix(key, "y-"):
+		side = "block-end"
+	default:
+		return Rule{}, false
+	}
+	value := "1px"
+	if len(key) > 1 {
+		width, ok := borderWidthValue(key[2:], borderWidth)
+		if !ok {
+			return Rule{}, false
+		}
+		value = width
+	}
+	return Rule{
+		Suffix: siblingSuffix,
+		Decls: []Decl{
+			{Property: "border-" + side + "-width", Value: value},
+			{Property: "border-" + side + "-style", Value: "solid"},
+		},
+	}, true
+}
+
 func matchSizing(base string, space, size, maxWidth, maxHeight, container map[string]string) ([]Decl, bool) {
 	if base == "container" {
 		key := defaultKey(container, "default", "lg", "xl", "md", "sm")
@@ -394,18 +593,144 @@ func sizeValueFromScale(key string, primary, size, space map[string]string, isWi
 
 func matchDisplay(base string) ([]Decl, bool) {
 	values := map[string]string{
-		"block":        "block",
-		"inline-block": "inline-block",
-		"inline":       "inline",
-		"flex":         "flex",
-		"inline-flex":  "inline-flex",
-		"grid":         "grid",
-		"hidden":       "none",
-		"contents":     "contents",
+		"block":              "block",
+		"inline-block":       "inline-block",
+		"inline":             "inline",
+		"flex":               "flex",
+		"inline-flex":        "inline-flex",
+		"grid":               "grid",
+		"inline-grid":        "inline-grid",
+		"hidden":             "none",
+		"contents":           "contents",
+		"flow-root":          "flow-root",
+		"list-item":          "list-item",
+		"table":              "table",
+		"inline-table":       "inline-table",
+		"table-caption":      "table-caption",
+		"table-cell":         "table-cell",
+		"table-column":       "table-column",
+		"table-column-group": "table-column-group",
+		"table-footer-group": "table-footer-group",
+		"table-header-group": "table-header-group",
+		"table-row-group":    "table-row-group",
+		"table-row":          "table-row",
 	}
 	if value, ok := values[base]; ok {
 		return []Decl{{Property: "display", Value: value}}, true
 	}
+	switch base {
+	case "box-border", "box-content":
+		return []Decl{{Property: "box-sizing", Value: strings.TrimPrefix(base, "box-") + "-box"}}, true
+	}
+	return nil, false
+}
+
+func matchFlexItem(base string, space, size map[string]string) ([]Decl, bool) {
+	switch base {
+	case "order-first":
+		return []Decl{{Property: "order", Value: "-9999"}}, true
+	case "order-last":
+		return []Decl{{Property: "order", Value: "9999"}}, true
+	case "order-none":
+		return []Decl{{Property: "order", Value: "0"}}, true
+	}
+	if strings.HasPrefix(base, "order-") {
+		if value, ok := parsePositiveInt(strings.TrimPrefix(base, "order-")); ok {
+			return []Decl{{Property: "order", Value: fmt.Sprintf("%d", value)}}, true
+		}
+		return nil, false
+	}
+	if strings.HasPrefix(base, "basis-") {
+		if value, ok := sizeValue(strings.TrimPrefix(base, "basis-"), size, space, true); ok {
+			return []Decl{{Property: "flex-basis", Value: value}}, true
+		}
+	}
+	return nil, false
+}
+
+func matchColumns(base string, size map[string]string) ([]Decl, bool) {
+	if strings.HasPrefix(base, "columns-") {
+		key := strings.TrimPrefix(base, "columns-")
+		if key == "auto" {
+			return []Decl{{Property: "columns", Value: "auto"}}, true
+		}
+		if value, ok := parsePositiveInt(key); ok {
+			return []Decl{{Property: "columns", Value: fmt.Sprintf("%d", value)}}, true
+		}
+		if _, ok := size[key]; ok {
+			return []Decl{{Property: "columns", Value: fmt.Sprintf("var(--size-%s)", key)}}, true
+		}
+		return nil, false
+	}
+	if strings.HasPrefix(base, "break-inside-") {
+		switch value := strings.TrimPrefix(base, "break-inside-"); value {
+		case "auto", "avoid", "avoid-page", "avoid-column":
+			return []Decl{{Property: "break-inside", Value: value}}, true
+		}
+		return nil, false
+	}
+	for _, property := range []string{"break-before", "break-after"} {
+		if !strings.HasPrefix(base, property+"-") {
+			continue
+		}
+		switch value := strings.TrimPrefix(base, property+"-"); value {
+		case "auto", "avoid", "all", "avoid-page", "page", "left", "right", "column":
+			return []Decl{{Property: property, Value: value}}, true
+		}
+		return nil, false
+	}
+	return nil, false
+}
+
+func matchFloat(base string) ([]Decl, bool) {
+	for _, property := range []string{"float", "clear"} {
+		if !strings.HasPrefix(base, property+"-") {
+			continue
+		}
+		switch value := strings.TrimPrefix(base, property+"-"); value {
+		case "left", "right", "none":
+			return []Decl{{Property: property, Value: value}}, true
+		case "start", "end":
+			return []Decl{{Property: property, Value: "inline-" + value}}, true
+		case "both":
+			if property == "clear" {
+				return []Decl{{Property: property, Value: value}}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func matchTable(base string, space map[string]string) ([]Decl, bool) {
+	switch base {
+	case "border-collapse", "border-separate":
+		return []Decl{{Property: "border-collapse", Value: strings.TrimPrefix(base, "border-")}}, true
+	case "table-auto", "table-fixed":
+		return []Decl{{Property: "table-layout", Value: strings.TrimPrefix(base, "table-")}}, true
+	case "caption-top", "caption-bottom":
+		return []Decl{{Property: "caption-side", Value: strings.TrimPrefix(base, "caption-")}}, true
+	}
+	for _, prefix := range []string{"border-spacing-x-", "border-spacing-y-", "border-spacing-"} {
+		if !strings.HasPrefix(base, prefix) {
+			continue
+		}
+		key := strings.TrimPrefix(base, prefix)
+		if _, ok := space[key]; !ok {
+			return nil, false
+		}
+		value := fmt.Sprintf("var(--space-%s)", key)
+		switch prefix {
+		case "border-spacing-x-":
+			return []Decl{{Property: "--lc-border-spacing-x", Value: value}, {Property: "border-spacing", Value: "var(--lc-border-spacing-x) var(--lc-border-spacing-y, 0)"}}, true
+		case "border-spacing-y-":
+			return []Decl{{Property: "--lc-border-spacing-y", Value: value}, {Property: "border-spacing", Value: "var(--lc-border-spacing-x, 0) var(--lc-border-spacing-y)"}}, true
+		}
+		return []Decl{
+			{Property: "--lc-border-spacing-x", Value: value},
+			{Property: "--lc-border-spacing-y", Value: value},
+			{Property: "border-spacing", Value: "var(--lc-border-spacing-x) var(--lc-border-spacing-y)"},
+		}, true
+	}
 	return nil, false
 }
 
@@ -505,8 +830,8 @@ func matchTypography(base string, fonts, fontSize, lineHeight, fontWeight, color
 		if _, ok := fontSize[key]; ok {
 			return []Decl{{Property: "font-size", Value: fmt.Sprintf("var(--font-size-%s)", key)}}, true
 		}
-		if _, ok := colors[key]; ok {
-			return []Decl{{Property: "color", Value: fmt.Sprintf("var(--color-%s)", key)}}, true
+		if color, ok := colorValue(key, colors); ok {
+			return []Decl{{Property: "color", Value: color}}, true
 		}
 		switch key {
 		case "left", "center", "right", "justify":
@@ -563,6 +888,125 @@ func matchTypographyExtras(base string, letterSpacing map[string]string) ([]Decl
 	return nil, false
 }
 
+const fontVariantNumericValue = "var(--lc-ordinal,) var(--lc-slashed-zero,) var(--lc-numeric-figure,) var(--lc-numeric-spacing,) var(--lc-numeric-fraction,)"
+
+func matchFontFeatures(base string) ([]Decl, bool) {
+	numeric := map[string]string{
+		"ordinal":            "--lc-ordinal",
+		"slashed-zero":       "--lc-slashed-zero",
+		"lining-nums":        "--lc-numeric-figure",
+		"oldstyle-nums":      "--lc-numeric-figure",
+		"proportional-nums":  "--lc-numeric-spacing",
+		"tabular-nums":       "--lc-numeric-spacing",
+		"diagonal-fractions": "--lc-numeric-fraction",
+		"stacked-fractions":  "--lc-numeric-fraction",
+	}
+	if property, ok := numeric[base]; ok {
+		return []Decl{
+			{Property: property, Value: base},
+			{Property: "font-variant-numeric", Value: fontVariantNumericValue},
+		}, true
+	}
+	switch base {
+	case "normal-nums":
+		return []Decl{{Property: "font-variant-numeric", Value: "normal"}}, true
+	case "antialiased":
+		return []Decl{
+			{Property: "-webkit-font-smoothing", Value: "antialiased"},
+			{Property: "-moz-osx-font-smoothing", Value: "grayscale"},
+		}, true
+	case "subpixel-antialiased":
+		return []Decl{
+			{Property: "-webkit-font-smoothing", Value: "auto"},
+			{Property: "-moz-osx-font-smoothing", Value: "auto"},
+		}, true
+	}
+	return nil, false
+}
+
+func matchDecoration(base string, colors, borderWidth map[string]string) ([]Decl, bool) {
+	if strings.HasPrefix(base, "underline-offset-") {
+		key := strings.TrimPrefix(base, "underline-offset-")
+		if key == "auto" {
+			return []Decl{{Property: "text-underline-offset", Value: "auto"}}, true
+		}
+		if value, ok := borderWidthValue(key, borderWidth); ok {
+			return []Decl{{Property: "text-underline-offset", Value: value}}, true
+		}
+		return nil, false
+	}
+	if !strings.HasPrefix(base, "decoration-") {
+		return nil, false
+	}
+	key := strings.TrimPrefix(base, "decoration-")
+	switch key {
+	case "solid", "double", "dotted", "dashed", "wavy":
+		return []Decl{{Property: "text-decoration-style", Value: key}}, true
+	case "auto", "from-font":
+		return []Decl{{Property: "text-decoration-thickness", Value: key}}, true
+	}
+	if color, ok := colorValue(key, colors); ok {
+		return []Decl{{Property: "text-decoration-color", Value: color}}, true
+	}
+	if value, ok := borderWidthValue(key, borderWidth); ok {
+		return []Decl{{Property: "text-decoration-thickness", Value: value}}, true
+	}
+	return nil, false
+}
+
+func matchTextFlow(base string, lineClamp int) ([]Decl, bool) {
+	switch base {
+	case "truncate":
+		return []Decl{
+			{Property: "overflow", Value: "hidden"},
+			{Property: "text-overflow", Value: "ellipsis"},
+			{Property: "white-space", Value: "nowrap"},
+		}, true
+	case "text-ellipsis", "text-clip":
+		return []Decl{{Property: "text-overflow", Value: strings.TrimPrefix(base, "text-")}}, true
+	case "text-wrap", "text-nowrap", "text-balance", "text-pretty":
+		return []Decl{{Property: "text-wrap", Value: strings.TrimPrefix(base, "text-")}}, true
+	case "whitespace-normal", "whitespace-nowrap", "whitespace-pre", "whitespace-pre-line", "whitespace-pre-wrap", "whitespace-break-spaces":
+		return []Decl{{Property: "white-space", Value: strings.TrimPrefix(base, "whitespace-")}}, true
+	case "break-normal":
+		return []Decl{
+			{Property: "overflow-wrap", Value: "normal"},
+			{Property: "word-break", Value: "normal"},
+		}, true
+	case "break-words":
+		return []Decl{{Property: "overflow-wrap", Value: "break-word"}}, true
+	case "break-all":
+		return []Decl{{Property: "word-break", Value: "break-all"}}, true
+	case "break-keep":
+		return []Decl{{Property: "word-break", Value: "keep-all"}}, true
+	case "hyphens-none", "hyphens-manual", "hyphens-auto":
+		return []Decl{{Property: "hyphens", Value: strings.TrimPrefix(base, "hyphens-")}}, true
+	case "line-clamp-none":
+		return []Decl{
+			{Property: "overflow", Value: "visible"},
+			{Property: "display", Value: "block"},
+			{Property: "-webkit-box-orient", Value: "horizontal"},
+			{Property: "-webkit-line-clamp", Value: "unset"},
+		}, true
+	}
+	if strings.HasPrefix(base, "line-clamp-") {
+		if lineClamp == 0 {
+			lineClamp = defaultLineClamp
+		}
+		lines, ok := parsePositiveInt(strings.TrimPrefix(base, "line-clamp-"))
+		if !ok || lines > lineClamp {
+			return nil, false
+		}
+		return []Decl{
+			{Property: "overflow", Value: "hidden"},
+			{Property: "display", Value: "-webkit-box"},
+			{Property: "-webkit-box-orient", Value: "vertical"},
+			{Property: "-webkit-line-clamp", Value: fmtInt(lines)},
+		}, true
+	}
+	return nil, false
+}
+
 func matchPosition(base string, space, size map[string]string) ([]Decl, bool) {
 	switch base {
 	case "static", "relative", "absolute", "fixed", "sticky":
@@ -603,24 +1047,88 @@ func parseInset(base string) (string, []string) {
 	}
 }
 
-func matchGrid(base string) ([]Decl, bool) {
+func matchGrid(base string, size map[string]string, grid config.Grid) ([]Decl, bool) {
 	if strings.HasPrefix(base, "grid-cols-") {
 		key := strings.TrimPrefix(base, "grid-cols-")
-		if key == "none" {
-			return []Decl{{Property: "grid-template-columns", Value: "none"}}, true
+		switch key {
+		case "none", "subgrid":
+			return []Decl{{Property: "grid-template-columns", Value: key}}, true
 		}
 		if value, ok := parsePositiveInt(key); ok {
 			return []Decl{{Property: "grid-template-columns", Value: fmt.Sprintf("repeat(%d, minmax(0, 1fr))", value)}}, true
 		}
+		for _, repeat := range []string{"fill", "fit"} {
+			if !strings.HasPrefix(key, repeat+"-") {
+				continue
+			}
+			sizeKey := strings.TrimPrefix(key, repeat+"-")
+			if _, ok := size[sizeKey]; ok {
+				return []Decl{{Property: "grid-template-columns", Value: fmt.Sprintf("repeat(auto-%s, minmax(var(--size-%s), 1fr))", repeat, sizeKey)}}, true
+			}
+			return nil, false
+		}
+		if template, ok := grid.Templates[key]; ok && template.Columns != "" {
+			return []Decl{{Property: "grid-template-columns", Value: emit.ResolveTokenRefs(template.Columns)}}, true
+		}
 	}
 	if strings.HasPrefix(base, "grid-rows-") {
 		key := strings.TrimPrefix(base, "grid-rows-")
-		if key == "none" {
-			return []Decl{{Property: "grid-template-rows", Value: "none"}}, true
+		switch key {
+		case "none", "subgrid":
+			return []Decl{{Property: "grid-template-rows", Value: key}}, true
 		}
 		if value, ok := parsePositiveInt(key); ok {
 			return []Decl{{Property: "grid-template-rows", Value: fmt.Sprintf("repeat(%d, minmax(0, 1fr))", value)}}, true
 		}
+		if template, ok := grid.Templates[key]; ok && template.Rows != "" {
+			return []Decl{{Property: "grid-template-rows", Value: emit.ResolveTokenRefs(template.Rows)}}, true
+		}
+	}
+	if strings.HasPrefix(base, "grid-areas-") {
+		key := strings.TrimPrefix(base, "grid-areas-")
+		if key == "none" {
+			return []Decl{{Property: "grid-template-areas", Value: "none"}}, true
+		}
+		if template, ok := grid.Templates[key]; ok && len(template.Areas) > 0 {
+			rows := make([]string, 0, len(template.Areas))
+			for _, cells := range template.AreaCells() {
+				rows = append(rows, fmt.Sprintf("%q", strings.Join(cells, " ")))
+			}
+			return []Decl{{Property: "grid-template-areas", Value: strings.Join(rows, " ")}}, true
+		}
+	}
+	if strings.HasPrefix(base, "area-") {
+		key := strings.TrimPrefix(base, "area-")
+		if key == "auto" {
+			return []Decl{{Property: "grid-area", Value: "auto"}}, true
+		}
+		for _, name := range grid.AreaNames() {
+			if name == key {
+				return []Decl{{Property: "grid-area", Value: key}}, true
+			}
+		}
+	}
+	switch base {
+	case "grid-flow-row", "grid-flow-col", "grid-flow-dense", "grid-flow-row-dense", "grid-flow-col-dense":
+		value := strings.TrimPrefix(base, "grid-flow-")
+		value = strings.Replace(value, "col", "column", 1)
+		value = strings.Replace(value, "-", " ", 1)
+		return []Decl{{Property: "grid-auto-flow", Value: value}}, true
+	}
+	for _, auto := range []struct{ prefix, property string }{{"auto-cols-", "grid-auto-columns"}, {"auto-rows-", "grid-auto-rows"}} {
+		if !strings.HasPrefix(base, auto.prefix) {
+			continue
+		}
+		switch strings.TrimPrefix(base, auto.prefix) {
+		case "auto":
+			return []Decl{{Property: auto.property, Value: "auto"}}, true
+		case "min":
+			return []Decl{{Property: auto.property, Value: "min-content"}}, true
+		case "max":
+			return []Decl{{Property: auto.property, Value: "max-content"}}, true
+		case "fr":
+			return []Decl{{Property: auto.property, Value: "minmax(0, 1fr)"}}, true
+		}
 	}
 	if strings.HasPrefix(base, "col-span-") {
 		key := strings.TrimPrefix(base, "col-span-")
@@ -703,28 +1211,133 @@ func matchBackground(base string) ([]Decl, bool) {
 	return nil, false
 }
 
+const gradientStops = "var(--lc-gradient-from, transparent) var(--lc-gradient-from-position,), var(--lc-gradient-via,) var(--lc-gradient-to, transparent) var(--lc-gradient-to-position,)"
+
+var gradientDirections = map[string]string{
+	"t":  "to top",
+	"tr": "to top right",
+	"r":  "to right",
+	"br": "to bottom right",
+	"b":  "to bottom",
+	"bl": "to bottom left",
+	"l":  "to left",
+	"tl": "to top left",
+}
+
+func matchGradient(base string, colors, gradientStop map[string]string) ([]Decl, bool) {
+	switch base {
+	case "bg-none":
+		return []Decl{{Property: "background-image", Value: "none"}}, true
+	case "bg-radial":
+		return []Decl{{Property: "background-image", Value: fmt.Sprintf("radial-gradient(%s)", gradientStops)}}, true
+	case "bg-conic":
+		return []Decl{{Property: "background-image", Value: fmt.Sprintf("conic-gradient(%s)", gradientStops)}}, true
+	}
+	if strings.HasPrefix(base, "bg-linear-to-") {
+		key := strings.TrimPrefix(base, "bg-linear-to-")
+		if direction, ok := gradientDirections[key]; ok {
+			return []Decl{{Property: "background-image", Value: fmt.Sprintf("linear-gradient(%s, %s)", direction, gradientStops)}}, true
+		}
+	}
+	for _, stop := range []string{"from", "via", "to"} {
+		if !strings.HasPrefix(base, stop+"-") {
+			continue
+		}
+		key := strings.TrimPrefix(base, stop+"-")
+		if color, ok := colorValue(key, colors); ok {
+			if stop == "via" {
+				return []Decl{{Property: "--lc-gradient-via", Value: color + " var(--lc-gradient-via-position,),"}}, true
+			}
+			return []Decl{{Property: "--lc-gradient-" + stop, Value: color}}, true
+		}
+		if _, ok := gradientStop[key]; ok {
+			return []Decl{{Property: "--lc-gradient-" + stop + "-position", Value: fmt.Sprintf("var(--gradient-stop-%s)", key)}}, true
+		}
+	}
+	return nil, false
+}
+
 func matchColors(base string, colors map[string]string) ([]Decl, bool) {
 	if strings.HasPrefix(base, "bg-") {
 		key := strings.TrimPrefix(base, "bg-")
-		if _, ok := colors[key]; ok {
-			return []Decl{{Property: "background-color", Value: fmt.Sprintf("var(--color-%s)", key)}}, true
+		if color, ok := colorValue(key, colors); ok {
+			return []Decl{{Property: "background-color", Value: color}}, true
 		}
 	}
 	if strings.HasPrefix(base, "text-") {
 		key := strings.TrimPrefix(base, "text-")
-		if _, ok := colors[key]; ok {
-			return []Decl{{Property: "color", Value: fmt.Sprintf("var(--color-%s)", key)}}, true
+		if color, ok := colorValue(key, colors); ok {
+			return []Decl{{Property: "color", Value: color}}, true
 		}
 	}
 	if strings.HasPrefix(base, "border-") {
 		key := strings.TrimPrefix(base, "border-")
-		if _, ok := colors[key]; ok {
-			return []Decl{{Property: "border-color", Value: fmt.Sprintf("var(--color-%s)", key)}}, true
+		if color, ok := colorValue(key, colors); ok {
+			return []Decl{{Property: "border-color", Value: color}}, true
 		}
 	}
 	return nil, false
 }
 
+func matchControlColors(base string, colors, borderWidth map[string]string) ([]Decl, bool) {
+	switch base {
+	case "fill-none":
+		return []Decl{{Property: "fill", Value: "none"}}, true
+	case "stroke-none":
+		return []Decl{{Property: "stroke", Value: "none"}}, true
+	case "accent-auto":
+		return []Decl{{Property: "accent-color", Value: "auto"}}, true
+	case "caret-auto":
+		return []Decl{{Property: "caret-color", Value: "auto"}}, true
+	}
+	if strings.HasPrefix(base, "stroke-width-") {
+		if value, ok := borderWidthValue(strings.TrimPrefix(base, "stroke-width-"), borderWidth); ok {
+			return []Decl{{Property: "stroke-width", Value: value}}, true
+		}
+		return nil, false
+	}
+	families := []struct{ prefix, property string }{
+		{"fill-", "fill"},
+		{"stroke-", "stroke"},
+		{"accent-", "accent-color"},
+		{"caret-", "caret-color"},
+	}
+	for _, family := range families {
+		if !strings.HasPrefix(base, family.prefix) {
+			continue
+		}
+		if color, ok := colorValue(strings.TrimPrefix(base, family.prefix), colors); ok {
+			return []Decl{{Property: family.property, Value: color}}, true
+		}
+		return nil, false
+	}
+	return nil, false
+}
+
+func matchPlaceholder(base string, colors map[string]string) (Rule, bool) {
+	if !strings.HasPrefix(base, "placeholder-") {
+		return Rule{}, false
+	}
+	color, ok := colorValue(strings.TrimPrefix(base, "placeholder-"), colors)
+	if !ok {
+		return Rule{}, false
+	}
+	return Rule{Suffix: "::placeholder", Decls: []Decl{{Property: "color", Value: color}}}, true
+}
+
+func colorValue(key string, colors map[string]string) (string, bool) {
+	if _, ok := colors[key]; ok {
+		return fmt.Sprintf("var(--color-%s)", key), true
+	}
+	switch key {
+	case "current":
+		return "currentColor", true
+	case "transparent", "inherit":
+		return key, true
+	}
+	return "", false
+}
+
 func matchBorders(base string, colors, borderWidth map[string]string) ([]Decl, bool) {
 	if base == "border" {
 		return []Decl{
@@ -739,7 +1352,7 @@ func matchBorders(base string, colors, borderWidth map[string]string) ([]Decl, b
 	}
 	if strings.HasPrefix(base, "border-") {
 		key := strings.TrimPrefix(base, "border-")
-		if _, ok := colors[key]; ok {
+		if _, ok := colorValue(key, colors); ok {
 			return nil, false
 		}
 		if _, ok := borderWidth[key]; ok {
@@ -803,6 +1416,43 @@ func matchBorders(base string, colors, borderWidth map[string]string) ([]Decl, b
 	return nil, false
 }
 
+func matchOutline(base string, colors, borderWidth map[string]string) ([]Decl, bool) {
+	switch base {
+	case "outline":
+		return []Decl{
+			{Property: "outline-width", Value: "1px"},
+			{Property: "outline-style", Value: "solid"},
+		}, true
+	case "outline-none":
+		return []Decl{
+			{Property: "outline", Value: "2px solid transparent"},
+			{Property: "outline-offset", Value: "2px"},
+		}, true
+	case "outline-solid", "outline-dashed", "outline-dotted", "outline-double":
+		return []Decl{{Property: "outline-style", Value: strings.TrimPrefix(base, "outline-")}}, true
+	}
+	if strings.HasPrefix(base, "outline-offset-") {
+		key := strings.TrimPrefix(base, "outline-offset-")
+		if value, ok := borderWidthValue(key, borderWidth); ok {
+			return []Decl{{Property: "outline-offset", Value: value}}, true
+		}
+		return nil, false
+	}
+	if strings.HasPrefix(base, "outline-") {
+		key := strings.TrimPrefix(base, "outline-")
+		if color, ok := colorValue(key, colors); ok {
+			return []Decl{{Property: "outline-color", Value: color}}, true
+		}
+		if value, ok := borderWidthValue(key, borderWidth); ok {
+			return []Decl{
+				{Property: "outline-width", Value: value},
+				{Property: "outline-style", Value: "solid"},
+			}, true
+		}
+	}
+	return nil, false
+}
+
 func borderWidthValue(key string, borderWidth map[string]string) (string, bool) {
 	if _, ok := borderWidth[key]; ok {
 		return fmt.Sprintf("var(--border-width-%s)", key), true
@@ -862,26 +1512,71 @@ func radiusCorners(key, value string) []Decl {
 	}
 }
 
+const boxShadowValue = "var(--lc-ring-offset-shadow, 0 0 #0000), var(--lc-ring-shadow, 0 0 #0000), var(--lc-shadow, 0 0 #0000)"
+
 func matchShadow(base string, shadow map[string]string) ([]Decl, bool) {
 	if base == "shadow" {
 		key := defaultKey(shadow, "default", "md", "sm", "lg", "xl")
 		if key == "" {
 			return nil, false
 		}
-		return []Decl{{Property: "box-shadow", Value: fmt.Sprintf("var(--shadow-%s)", key)}}, true
+		return shadowDecls("--lc-shadow", fmt.Sprintf("var(--shadow-%s)", key)), true
 	}
 	if strings.HasPrefix(base, "shadow-") {
 		key := strings.TrimPrefix(base, "shadow-")
 		if _, ok := shadow[key]; ok {
-			return []Decl{{Property: "box-shadow", Value: fmt.Sprintf("var(--shadow-%s)", key)}}, true
+			return shadowDecls("--lc-shadow", fmt.Sprintf("var(--shadow-%s)", key)), true
 		}
 	}
 	if base == "shadow-none" {
-		return []Decl{{Property: "box-shadow", Value: "none"}}, true
+		return shadowDecls("--lc-shadow", "0 0 #0000"), true
 	}
 	return nil, false
 }
 
+func shadowDecls(property, value string) []Decl {
+	return []Decl{
+		{Property: property, Value: value},
+		{Property: "box-shadow", Value: boxShadowValue},
+	}
+}
+
+func matchRing(base string, colors, ring map[string]string) ([]Decl, bool) {
+	if base == "ring-inset" {
+		return []Decl{{Property: "--lc-ring-inset", Value: "inset"}}, true
+	}
+	if strings.HasPrefix(base, "ring-offset-") {
+		key := strings.TrimPrefix(base, "ring-offset-")
+		if color, ok := colorValue(key, colors); ok {
+			return []Decl{{Property: "--lc-ring-offset-color", Value: color}}, true
+		}
+		if _, ok := ring[key]; ok {
+			return []Decl{{Property: "--lc-ring-offset-width", Value: fmt.Sprintf("var(--ring-%s)", key)}}, true
+		}
+		return nil, false
+	}
+	key := ""
+	if base == "ring" {
+		key = defaultKey(ring, "default", "2", "1")
+	} else if strings.HasPrefix(base, "ring-") {
+		key = strings.TrimPrefix(base, "ring-")
+		if color, ok := colorValue(key, colors); ok {
+			return []Decl{{Property: "--lc-ring-color", Value: color}}, true
+		}
+		if _, ok := ring[key]; !ok {
+			return nil, false
+		}
+	}
+	if key == "" {
+		return nil, false
+	}
+	return []Decl{
+		{Property: "--lc-ring-offset-shadow", Value: "var(--lc-ring-inset,) 0 0 0 var(--lc-ring-offset-width, 0px) var(--lc-ring-offset-color, #fff)"},
+		{Property: "--lc-ring-shadow", Value: fmt.Sprintf("var(--lc-ring-inset,) 0 0 0 calc(var(--ring-%s) + var(--lc-ring-offset-width, 0px)) var(--lc-ring-color, currentColor)", key)},
+		{Property: "box-shadow", Value: boxShadowValue},
+	}, true
+}
+
 func matchOpacity(base string, opacity map[string]string) ([]Decl, bool) {
 	if strings.HasPrefix(base, "opacity-") {
 		key := strings.TrimPrefix(base, "opacity-")
@@ -892,6 +1587,80 @@ func matchOpacity(base string, opacity map[string]string) ([]Decl, bool) {
 	return nil, false
 }
 
+const (
+	filterValue         = "var(--lc-blur,) var(--lc-brightness,) var(--lc-contrast,) var(--lc-grayscale,) var(--lc-saturate,) var(--lc-drop-shadow,)"
+	backdropFilterValue = "var(--lc-backdrop-blur,) var(--lc-backdrop-brightness,) var(--lc-backdrop-contrast,) var(--lc-backdrop-grayscale,) var(--lc-backdrop-saturate,)"
+)
+
+func matchFilter(base string, blur, brightness, dropShadow map[string]string) ([]Decl, bool) {
+	switch base {
+	case "filter-none":
+		return []Decl{{Property: "filter", Value: "none"}}, true
+	case "backdrop-filter-none":
+		return []Decl{{Property: "backdrop-filter", Value: "none"}}, true
+	case "drop-shadow":
+		key := defaultKey(dropShadow, "default", "md", "sm")
+		if key == "" {
+			return nil, false
+		}
+		return filterDecls("filter", "drop-shadow", fmt.Sprintf("drop-shadow(var(--drop-shadow-%s))", key)), true
+	case "drop-shadow-none":
+		return filterDecls("filter", "drop-shadow", "drop-shadow(0 0 #0000)"), true
+	}
+	if strings.HasPrefix(base, "drop-shadow-") {
+		key := strings.TrimPrefix(base, "drop-shadow-")
+		if _, ok := dropShadow[key]; ok {
+			return filterDecls("filter", "drop-shadow", fmt.Sprintf("drop-shadow(var(--drop-shadow-%s))", key)), true
+		}
+		return nil, false
+	}
+
+	property, prefix, name := "filter", "", base
+	if strings.HasPrefix(base, "backdrop-") {
+		property, prefix, name = "backdrop-filter", "backdrop-", strings.TrimPrefix(base, "backdrop-")
+	}
+
+	switch name {
+	case "blur":
+		key := defaultKey(blur, "default", "md", "sm")
+		if key == "" {
+			return nil, false
+		}
+		return filterDecls(property, prefix+"blur", fmt.Sprintf("blur(var(--blur-%s))", key)), true
+	case "grayscale":
+		return filterDecls(property, prefix+"grayscale", "grayscale(100%)"), true
+	case "grayscale-0":
+		return filterDecls(property, prefix+"grayscale", "grayscale(0%)"), true
+	}
+	if strings.HasPrefix(name, "blur-") {
+		key := strings.TrimPrefix(name, "blur-")
+		if _, ok := blur[key]; ok {
+			return filterDecls(property, prefix+"blur", fmt.Sprintf("blur(var(--blur-%s))", key)), true
+		}
+	}
+	for _, fn := range []string{"brightness", "contrast", "saturate"} {
+		if !strings.HasPrefix(name, fn+"-") {
+			continue
+		}
+		key := strings.TrimPrefix(name, fn+"-")
+		if _, ok := brightness[key]; ok {
+			return filterDecls(property, prefix+fn, fmt.Sprintf("%s(var(--brightness-%s))", fn, key)), true
+		}
+	}
+	return nil, false
+}
+
+func filterDecls(property, name, value string) []Decl {
+	composed := filterValue
+	if property == "backdrop-filter" {
+		composed = backdropFilterValue
+	}
+	return []Decl{
+		{Property: "--lc-" + name, Value: value},
+		{Property: property, Value: composed},
+	}
+}
+
 func matchZIndex(base string, zIndex map[string]string) ([]Decl, bool) {
 	if base == "z-auto" {
 		return []Decl{{Property: "z-index", Value: "auto"}}, true
@@ -997,6 +1766,55 @@ func matchTransition(base string, duration, easing, delay map[string]string) ([]
 	return nil, false
 }
 
+const reducedMotionMedia = "(prefers-reduced-motion: reduce)"
+
+func matchAnimation(base string, animation map[string]string, keyframes map[string]config.Keyframe) (Rule, bool) {
+	if base == "animate-none" {
+		return Rule{Decls: []Decl{{Property: "animation", Value: "none"}}}, true
+	}
+	if strings.HasPrefix(base, "animate-") {
+		key := strings.TrimPrefix(base, "animate-")
+		if value, ok := animation[key]; ok {
+			var names []string
+			for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' }) {
+				if _, ok := keyframes[field]; ok {
+					names = append(names, field)
+				}
+			}
+			return Rule{
+				Decls:     []Decl{{Property: "animation", Value: fmt.Sprintf("var(--animation-%s)", key)}},
+				Keyframes: names,
+			}, true
+		}
+	}
+	return Rule{}, false
+}
+
+func matchReducedMotion(base string, animation map[string]string) ([]Decl, bool) {
+	if !strings.HasPrefix(base, "animate-") {
+		return nil, false
+	}
+	if _, ok := animation[strings.TrimPrefix(base, "animate-")]; !ok {
+		return nil, false
+	}
+	return []Decl{{Property: "animation", Value: "none"}}, true
+}
+
+func usedKeyframes(rules []Rule) []string {
+	set := map[string]struct{}{}
+	for _, rule := range rules {
+		for _, name := range rule.Keyframes {
+			set[name] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func matchTransform(base string, translate, rotate, scale, space map[string]string) ([]Decl, bool) {
 	if strings.HasPrefix(base, "translate-x-") {
 		key := strings.TrimPrefix(base, "translate-x-")
@@ -1038,10 +1856,84 @@ func transformValue(key string, translate, space map[string]string) (string, boo
 	return "", false
 }
 
+var cursorValues = []string{
+	"auto", "default", "pointer", "wait", "text", "move", "help", "not-allowed", "none",
+	"context-menu", "progress", "cell", "crosshair", "vertical-text", "alias", "copy", "no-drop",
+	"grab", "grabbing", "all-scroll", "col-resize", "row-resize",
+	"n-resize", "e-resize", "s-resize", "w-resize", "ne-resize", "nw-resize", "se-resize", "sw-resize",
+	"ew-resize", "ns-resize", "nesw-resize", "nwse-resize", "zoom-in", "zoom-out",
+}
+
+var blendModes = []string{
+	"normal", "multiply", "screen", "overlay", "darken", "lighten", "color-dodge", "color-burn",
+	"hard-light", "soft-light", "difference", "exclusion", "hue", "saturation", "color", "luminosity",
+}
+
+const touchActionValue = "var(--lc-pan-x,) var(--lc-pan-y,) var(--lc-pinch-zoom,)"
+
+func matchBlend(base string) ([]Decl, bool) {
+	if base == "mix-blend-plus-lighter" {
+		return []Decl{{Property: "mix-blend-mode", Value: "plus-lighter"}}, true
+	}
+	for _, family := range []struct{ prefix, property string }{{"mix-blend-", "mix-blend-mode"}, {"bg-blend-", "background-blend-mode"}} {
+		if !strings.HasPrefix(base, family.prefix) {
+			continue
+		}
+		value := strings.TrimPrefix(base, family.prefix)
+		for _, mode := range blendModes {
+			if mode == value {
+				return []Decl{{Property: family.property, Value: value}}, true
+			}
+		}
+		return nil, false
+	}
+	return nil, false
+}
+
 func matchInteraction(base string) ([]Decl, bool) {
+	if strings.HasPrefix(base, "cursor-") {
+		value := strings.TrimPrefix(base, "cursor-")
+		for _, cursor := range cursorValues {
+			if cursor == value {
+				return []Decl{{Property: "cursor", Value: value}}, true
+			}
+		}
+		return nil, false
+	}
 	switch base {
-	case "cursor-pointer", "cursor-default", "cursor-text", "cursor-not-allowed":
-		return []Decl{{Property: "cursor", Value: strings.TrimPrefix(base, "cursor-")}}, true
+	case "touch-auto", "touch-none", "touch-manipulation":
+		return []Decl{{Property: "touch-action", Value: strings.TrimPrefix(base, "touch-")}}, true
+	case "touch-pan-x", "touch-pan-left", "touch-pan-right":
+		return []Decl{
+			{Property: "--lc-pan-x", Value: strings.TrimPrefix(base, "touch-")},
+			{Property: "touch-action", Value: touchActionValue},
+		}, true
+	case "touch-pan-y", "touch-pan-up", "touch-pan-down":
+		return []Decl{
+			{Property: "--lc-pan-y", Value: strings.TrimPrefix(base, "touch-")},
+			{Property: "touch-action", Value: touchActionValue},
+		}, true
+	case "touch-pinch-zoom":
+		return []Decl{
+			{Property: "--lc-pinch-zoom", Value: "pinch-zoom"},
+			{Property: "touch-action", Value: touchActionValue},
+		}, true
+	case "appearance-none", "appearance-auto":
+		return []Decl{{Property: "appearance", Value: strings.TrimPrefix(base, "appearance-")}}, true
+	case "resize-none":
+		return []Decl{{Property: "resize", Value: "none"}}, true
+	case "resize":
+		return []Decl{{Property: "resize", Value: "both"}}, true
+	case "resize-x":
+		return []Decl{{Property: "resize", Value: "horizontal"}}, true
+	case "resize-y":
+		return []Decl{{Property: "resize", Value: "vertical"}}, true
+	case "will-change-auto", "will-change-contents", "will-change-transform", "will-change-opacity":
+		return []Decl{{Property: "will-change", Value: strings.TrimPrefix(base, "will-change-")}}, true
+	case "will-change-scroll":
+		return []Decl{{Property: "will-change", Value: "scroll-position"}}, true
+	case "content-visibility-visible", "content-visibility-auto", "content-visibility-hidden":
+		return []Decl{{Property: "content-visibility", Value: strings.TrimPrefix(base, "content-visibility-")}}, true
 	case "pointer-events-none":
 		return []Decl{{Property: "pointer-events", Value: "none"}}, true
 	case "pointer-events-auto":
@@ -1104,6 +1996,33 @@ func renderRules(rules []Rule) string {
 	return b.String()
 }
 
+func renderProperties(rules []Rule) string {
+	set := map[string]struct{}{}
+	for _, rule := range rules {
+		for _, decl := range rule.Decls {
+			for _, name := range composedVarPattern.FindAllString(decl.Property+" "+decl.Value, -1) {
+				set[name] = struct{}{}
+			}
+		}
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString("@property ")
+		b.WriteString(name)
+		b.WriteString(" {\n")
+		b.WriteString("  syntax: \"*\";\n")
+		b.WriteString("  inherits: false;\n")
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
 func writeRule(b *strings.Builder, rule Rule) {
 	if rule.Media != "" {
 		b.WriteString("@media ")
@@ -1119,6 +2038,7 @@ func writeRule(b *strings.Builder, rule Rule) {
 func writeRuleBody(b *strings.Builder, rule Rule, indent string) {
 	b.WriteString(indent)
 	b.WriteString(rule.Selector)
+	b.WriteString(rule.Suffix)
 	b.WriteString(" {\n")
 	for _, decl := range rule.Decls {
 		b.WriteString(indent)
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"sort"
+	"strings"
 
 	_ "embed"
 )
@@ -15,16 +17,27 @@ import (
 //go:embed default.json
 var defaultConfigJSON []byte
 
+var (
+	TokenRefPattern     = regexp.MustCompile(`\{([a-zA-Z]+)\.([a-zA-Z0-9_-]+)\}`)
+	keyframeNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+	keyframeStepPattern = regexp.MustCompile(`^(from|to|\d+(\.\d+)?%)$`)
+	gridNamePattern     = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+	gridNullCellPattern = regexp.MustCompile(`^\.+$`)
+)
+
 type Config struct {
-	SchemaVersion int               `json:"schemaVersion"`
-	ClassPrefix   string            `json:"classPrefix,omitempty"`
-	Separator     string            `json:"separator,omitempty"`
-	Breakpoints   map[string]string `json:"breakpoints,omitempty"`
-	Themes        map[string]Theme  `json:"themes,omitempty"`
-	Fonts         Fonts             `json:"fonts,omitempty"`
-	Scales        Scales            `json:"scales,omitempty"`
-	Variants      Variants          `json:"variants,omitempty"`
-	Build         Build             `json:"build,omitempty"`
+	SchemaVersion int                 `json:"schemaVersion"`
+	ClassPrefix   string              `json:"classPrefix,omitempty"`
+	Separator     string              `json:"separator,omitempty"`
+	Breakpoints   map[string]string   `json:"breakpoints,omitempty"`
+	Themes        map[string]Theme    `json:"themes,omitempty"`
+	Fonts         Fonts               `json:"fonts,omitempty"`
+	Scales        Scales              `json:"scales,omitempty"`
+	Keyframes     map[string]Keyframe `json:"keyframes,omitempty"`
+	Prose         Prose               `json:"prose,omitempty"`
+	Grid          Grid                `json:"grid,omitempty"`
+	Variants      Variants            `json:"variants,omitempty"`
+	Build         Build               `json:"build,omitempty"`
 }
 
 func (c Config) ValidateMajorVersion(major int) error {
@@ -39,6 +52,73 @@ type Theme struct {
 	Font   map[string]string `json:"font,omitempty"`
 }
 
+type Keyframe map[string]map[string]string
+
+type Prose struct {
+	Sizes  map[string]ProseSize  `json:"sizes,omitempty"`
+	Colors map[string]ProseColor `json:"colors,omitempty"`
+}
+
+type ProseSize struct {
+	FontSize          string `json:"fontSize,omitempty"`
+	LineHeight        string `json:"lineHeight,omitempty"`
+	Spacing           string `json:"spacing,omitempty"`
+	Indent            string `json:"indent,omitempty"`
+	H1                string `json:"h1,omitempty"`
+	H2                string `json:"h2,omitempty"`
+	H3                string `json:"h3,omitempty"`
+	H4                string `json:"h4,omitempty"`
+	HeadingLineHeight string `json:"headingLineHeight,omitempty"`
+}
+
+type ProseColor struct {
+	Body     string `json:"body,omitempty"`
+	Headings string `json:"headings,omitempty"`
+	Links    string `json:"links,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Pre      string `json:"pre,omitempty"`
+	Quotes   string `json:"quotes,omitempty"`
+	Borders  string `json:"borders,omitempty"`
+	Bullets  string `json:"bullets,omitempty"`
+}
+
+type Grid struct {
+	Templates map[string]GridTemplate `json:"templates,omitempty"`
+}
+
+type GridTemplate struct {
+	Columns string   `json:"columns,omitempty"`
+	Rows    string   `json:"rows,omitempty"`
+	Areas   []string `json:"areas,omitempty"`
+}
+
+func (t GridTemplate) AreaCells() [][]string {
+	rows := make([][]string, 0, len(t.Areas))
+	for _, row := range t.Areas {
+		rows = append(rows, strings.Fields(row))
+	}
+	return rows
+}
+
+func (g Grid) AreaNames() []string {
+	set := map[string]struct{}{}
+	for _, template := range g.Templates {
+		for _, row := range template.AreaCells() {
+			for _, cell := range row {
+				if !gridNullCellPattern.MatchString(cell) {
+					set[cell] = struct{}{}
+				}
+			}
+		}
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 type Fonts struct {
 	Imports []string   `json:"imports,omitempty"`
 	Faces   []FontFace `json:"faces,omitempty"`
@@ -84,6 +164,69 @@ type Scales struct {
 	MaxWidth      map[string]string `json:"maxWidth,omitempty"`
 	MaxHeight     map[string]string `json:"maxHeight,omitempty"`
 	Container     map[string]string `json:"container,omitempty"`
+	GradientStop  map[string]string `json:"gradientStop,omitempty"`
+	Blur          map[string]string `json:"blur,omitempty"`
+	Brightness    map[string]string `json:"brightness,omitempty"`
+	DropShadow    map[string]string `json:"dropShadow,omitempty"`
+	Ring          map[string]string `json:"ring,omitempty"`
+	Animation     map[string]string `json:"animation,omitempty"`
+
+	// Fluid holds {min, max} entries keyed by scale name, then entry name.
+	Fluid map[string]map[string]FluidValue `json:"-"`
+}
+
+// MarshalJSON writes fluid entries back into their scales as objects.
+func (s Scales) MarshalJSON() ([]byte, error) {
+	type plainScales Scales
+	data, err := json.Marshal(plainScales(s))
+	if err != nil || len(s.Fluid) == 0 {
+		return data, err
+	}
+	var merged map[string]map[string]any
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for name, values := range s.Fluid {
+		if merged[name] == nil {
+			merged[name] = map[string]any{}
+		}
+		for key, value := range values {
+			merged[name][key] = value
+		}
+	}
+	return json.Marshal(merged)
+}
+
+func (s Scales) byName() map[string]map[string]string {
+	return map[string]map[string]string{
+		"space":         s.Space,
+		"size":          s.Size,
+		"radius":        s.Radius,
+		"borderWidth":   s.BorderWidth,
+		"fontSize":      s.FontSize,
+		"lineHeight":    s.LineHeight,
+		"fontWeight":    s.FontWeight,
+		"letterSpacing": s.LetterSpacing,
+		"shadow":        s.Shadow,
+		"z":             s.ZIndex,
+		"opacity":       s.Opacity,
+		"aspect":        s.Aspect,
+		"duration":      s.Duration,
+		"easing":        s.Easing,
+		"delay":         s.Delay,
+		"translate":     s.Translate,
+		"rotate":        s.Rotate,
+		"scale":         s.Scale,
+		"maxWidth":      s.MaxWidth,
+		"maxHeight":     s.MaxHeight,
+		"container":     s.Container,
+		"gradientStop":  s.GradientStop,
+		"blur":          s.Blur,
+		"brightness":    s.Brightness,
+		"dropShadow":    s.DropShadow,
+		"ring":          s.Ring,
+		"animation":     s.Animation,
+	}
 }
 
 type Variants struct {
@@ -92,11 +235,17 @@ type Variants struct {
 }
 
 type Build struct {
-	Content            []string    `json:"content,omitempty"`
-	Safelist           []string    `json:"safelist,omitempty"`
-	Emit               EmitOptions `json:"emit,omitempty"`
-	GridColumns        int         `json:"gridColumns,omitempty"`
-	UnknownClassPolicy string      `json:"unknownClassPolicy,omitempty"`
+	Content            []string          `json:"content,omitempty"`
+	Safelist           []string          `json:"safelist,omitempty"`
+	Blocklist          []string          `json:"blocklist,omitempty"`
+	Extractors         map[string]string `json:"extractors,omitempty"`
+	ClassFunctions     []string          `json:"classFunctions,omitempty"`
+	DataSelectors      []string          `json:"dataSelectors,omitempty"`
+	Emit               EmitOptions       `json:"emit,omitempty"`
+	GridColumns        int               `json:"gridColumns,omitempty"`
+	LineClamp          int               `json:"lineClamp,omitempty"`
+	Workers            int               `json:"workers,omitempty"`
+	UnknownClassPolicy string            `json:"unknownClassPolicy,omitempty"`
 }
 
 type EmitOptions struct {
@@ -153,6 +302,9 @@ func Load(basePath, sitePath string) (Config, error) {
 			return Config{}, fmt.Errorf("read base config: %w", err)
 		}
 	}
+	if err := expandScaleGenerators(baseMap); err != nil {
+		return Config{}, fmt.Errorf("expand base config: %w", err)
+	}
 
 	merged := baseMap
 	if sitePath != "" {
@@ -160,9 +312,17 @@ func Load(basePath, sitePath string) (Config, error) {
 		if err != nil {
 			return Config{}, fmt.Errorf("read site config: %w", err)
 		}
+		if err := expandScaleGenerators(siteMap); err != nil {
+			return Config{}, fmt.Errorf("expand site config: %w", err)
+		}
 		merged = mergeMaps(baseMap, siteMap)
 	}
 
+	fluids, err := extractFluidScales(merged)
+	if err != nil {
+		return Config{}, fmt.Errorf("decode fluid scales: %w", err)
+	}
+
 	data, err := json.Marshal(merged)
 	if err != nil {
 		return Config{}, fmt.Errorf("marshal merged config: %w", err)
@@ -172,6 +332,7 @@ func Load(basePath, sitePath string) (Config, error) {
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return Config{}, fmt.Errorf("decode merged config: %w", err)
 	}
+	cfg.Scales.Fluid = fluids
 
 	if cfg.Separator == "" {
 		cfg.Separator = ":"
@@ -185,6 +346,9 @@ func Load(basePath, sitePath string) (Config, error) {
 	if cfg.Build.GridColumns == 0 {
 		cfg.Build.GridColumns = 12
 	}
+	if cfg.Build.LineClamp == 0 {
+		cfg.Build.LineClamp = 6
+	}
 
 	return cfg, nil
 }
@@ -199,12 +363,24 @@ func (c Config) Validate() error {
 			return errors.New("themes.default is required")
 		}
 	}
-	if len(c.Scales.Space) == 0 {
+	if len(c.Scales.Space) == 0 && len(c.Scales.Fluid["space"]) == 0 {
 		return errors.New("scales.space is required")
 	}
 	if err := validateFonts(c.Fonts); err != nil {
 		return err
 	}
+	if err := validateKeyframes(c); err != nil {
+		return err
+	}
+	if err := validateProse(c); err != nil {
+		return err
+	}
+	if err := validateFluid(c); err != nil {
+		return err
+	}
+	if err := validateGrid(c); err != nil {
+		return err
+	}
 	if c.Build.UnknownClassPolicy != "" {
 		switch c.Build.UnknownClassPolicy {
 		case "ignore", "warn", "error":
@@ -216,6 +392,26 @@ func (c Config) Validate() error {
 	if c.Build.GridColumns < 0 {
 		return errors.New("build.gridColumns must be zero or greater")
 	}
+	if c.Build.LineClamp < 0 {
+		return errors.New("build.lineClamp must be zero or greater")
+	}
+	if c.Build.Workers < 0 {
+		return errors.New("build.workers must be zero or greater")
+	}
+	if err := validateClassPatterns("build.safelist", c.Build.Safelist); err != nil {
+		return err
+	}
+	if err := validateClassPatterns("build.blocklist", c.Build.Blocklist); err != nil {
+		return err
+	}
+	for ext, name := range c.Build.Extractors {
+		if strings.TrimSpace(ext) == "" || strings.Trim(ext, ".") == "" {
+			return errors.New("build.extractors keys must be file extensions like .vue")
+		}
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("build.extractors[%s] must name an extractor", ext)
+		}
+	}
 	if len(c.Variants.Responsive) > 0 {
 		if c.Breakpoints == nil {
 			return errors.New("breakpoints are required when variants.responsive is set")
@@ -246,6 +442,195 @@ func validateFonts(fonts Fonts) error {
 	return nil
 }
 
+func validateKeyframes(c Config) error {
+	if len(c.Keyframes) == 0 {
+		return nil
+	}
+	tokens := NormalizeTokens(c)
+	names := make([]string, 0, len(c.Keyframes))
+	for name := range c.Keyframes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !keyframeNamePattern.MatchString(name) {
+			return fmt.Errorf("keyframes.%s: invalid keyframes name", name)
+		}
+		for step, decls := range c.Keyframes[name] {
+			for _, part := range strings.Split(step, ",") {
+				if !keyframeStepPattern.MatchString(strings.TrimSpace(part)) {
+					return fmt.Errorf("keyframes.%s: invalid step %q (use from, to, or a percentage)", name, step)
+				}
+			}
+			for property, value := range decls {
+				for _, ref := range TokenRefPattern.FindAllStringSubmatch(value, -1) {
+					if !tokenExists(tokens, ref[1], ref[2]) {
+						return fmt.Errorf("keyframes.%s[%s].%s references unknown token %s", name, step, property, ref[0])
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateProse(c Config) error {
+	tokens := NormalizeTokens(c)
+	sizeNames := make([]string, 0, len(c.Prose.Sizes))
+	for name := range c.Prose.Sizes {
+		sizeNames = append(sizeNames, name)
+	}
+	sort.Strings(sizeNames)
+	for _, name := range sizeNames {
+		if _, ok := c.Prose.Colors[name]; ok && name != "default" {
+			return fmt.Errorf("prose.sizes.%s conflicts with prose.colors.%s", name, name)
+		}
+		size := c.Prose.Sizes[name]
+		refs := []struct {
+			field, scale, key string
+		}{
+			{"fontSize", "fontSize", size.FontSize},
+			{"lineHeight", "lineHeight", size.LineHeight},
+			{"spacing", "space", size.Spacing},
+			{"indent", "space", size.Indent},
+			{"h1", "fontSize", size.H1},
+			{"h2", "fontSize", size.H2},
+			{"h3", "fontSize", size.H3},
+			{"h4", "fontSize", size.H4},
+			{"headingLineHeight", "lineHeight", size.HeadingLineHeight},
+		}
+		for _, ref := range refs {
+			if ref.key != "" && !tokenExists(tokens, ref.scale, ref.key) {
+				return fmt.Errorf("prose.sizes.%s.%s references unknown %s key: %s", name, ref.field, ref.scale, ref.key)
+			}
+		}
+	}
+
+	colorNames := make([]string, 0, len(c.Prose.Colors))
+	for name := range c.Prose.Colors {
+		colorNames = append(colorNames, name)
+	}
+	sort.Strings(colorNames)
+	for _, name := range colorNames {
+		color := c.Prose.Colors[name]
+		refs := []struct {
+			field, key string
+		}{
+			{"body", color.Body},
+			{"headings", color.Headings},
+			{"links", color.Links},
+			{"code", color.Code},
+			{"pre", color.Pre},
+			{"quotes", color.Quotes},
+			{"borders", color.Borders},
+			{"bullets", color.Bullets},
+		}
+		for _, ref := range refs {
+			if ref.key != "" && !tokenExists(tokens, "color", ref.key) {
+				return fmt.Errorf("prose.colors.%s.%s references unknown color: %s", name, ref.field, ref.key)
+			}
+		}
+	}
+	return nil
+}
+
+func validateClassPatterns(field string, entries []string) error {
+	for i, entry := range entries {
+		if strings.TrimSpace(entry) == "" {
+			return fmt.Errorf("%s[%d] must not be empty", field, i)
+		}
+	}
+	return nil
+}
+
+func validateGrid(c Config) error {
+	tokens := NormalizeTokens(c)
+	names := make([]string, 0, len(c.Grid.Templates))
+	for name := range c.Grid.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !gridNamePattern.MatchString(name) {
+			return fmt.Errorf("grid.templates.%s: invalid template name", name)
+		}
+		switch {
+		case name == "none", name == "subgrid", strings.HasPrefix(name, "fill-"), strings.HasPrefix(name, "fit-"):
+			return fmt.Errorf("grid.templates.%s: name is reserved", name)
+		}
+		template := c.Grid.Templates[name]
+		if template.Columns == "" && template.Rows == "" && len(template.Areas) == 0 {
+			return fmt.Errorf("grid.templates.%s must set columns, rows, or areas", name)
+		}
+		for _, field := range []struct{ name, value string }{{"columns", template.Columns}, {"rows", template.Rows}} {
+			for _, ref := range TokenRefPattern.FindAllStringSubmatch(field.value, -1) {
+				if !tokenExists(tokens, ref[1], ref[2]) {
+					return fmt.Errorf("grid.templates.%s.%s references unknown token %s", name, field.name, ref[0])
+				}
+			}
+		}
+		if err := validateGridAreas(template.AreaCells()); err != nil {
+			return fmt.Errorf("grid.templates.%s.areas: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func validateGridAreas(rows [][]string) error {
+	type box struct{ top, left, bottom, right, cells int }
+	boxes := map[string]*box{}
+	for y, row := range rows {
+		if len(row) == 0 {
+			return fmt.Errorf("row %d is empty", y)
+		}
+		if len(row) != len(rows[0]) {
+			return fmt.Errorf("row %d has %d cells, expected %d", y, len(row), len(rows[0]))
+		}
+		for x, cell := range row {
+			if gridNullCellPattern.MatchString(cell) {
+				continue
+			}
+			if !gridNamePattern.MatchString(cell) {
+				return fmt.Errorf("invalid area name %q", cell)
+			}
+			b, ok := boxes[cell]
+			if !ok {
+				boxes[cell] = &box{top: y, left: x, bottom: y, right: x, cells: 1}
+				continue
+			}
+			b.top, b.bottom = min(b.top, y), max(b.bottom, y)
+			b.left, b.right = min(b.left, x), max(b.right, x)
+			b.cells++
+		}
+	}
+	names := make([]string, 0, len(boxes))
+	for name := range boxes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b := boxes[name]
+		if (b.bottom-b.top+1)*(b.right-b.left+1) != b.cells {
+			return fmt.Errorf("area %q is not rectangular", name)
+		}
+	}
+	return nil
+}
+
+func tokenExists(tokens CanonicalTokens, group, key string) bool {
+	switch group {
+	case "color":
+		_, ok := tokens.Themes["default"].Colors[key]
+		return ok
+	case "font":
+		_, ok := tokens.Themes["default"].Fonts[key]
+		return ok
+	default:
+		_, ok := tokens.Scales[group][key]
+		return ok
+	}
+}
+
 func (c Config) Canonicalize() Canonical {
 	return Canonical{
 		Config: c,
@@ -266,68 +651,22 @@ func NormalizeTokens(c Config) CanonicalTokens {
 		}
 	}
 
-	if c.Scales.Space != nil {
-		tokens.Scales["space"] = copyStringMap(c.Scales.Space)
-	}
-	if c.Scales.Size != nil {
-		tokens.Scales["size"] = copyStringMap(c.Scales.Size)
-	}
-	if c.Scales.Radius != nil {
-		tokens.Scales["radius"] = copyStringMap(c.Scales.Radius)
-	}
-	if c.Scales.BorderWidth != nil {
-		tokens.Scales["borderWidth"] = copyStringMap(c.Scales.BorderWidth)
-	}
-	if c.Scales.FontSize != nil {
-		tokens.Scales["fontSize"] = copyStringMap(c.Scales.FontSize)
-	}
-	if c.Scales.LineHeight != nil {
-		tokens.Scales["lineHeight"] = copyStringMap(c.Scales.LineHeight)
-	}
-	if c.Scales.FontWeight != nil {
-		tokens.Scales["fontWeight"] = copyStringMap(c.Scales.FontWeight)
-	}
-	if c.Scales.LetterSpacing != nil {
-		tokens.Scales["letterSpacing"] = copyStringMap(c.Scales.LetterSpacing)
-	}
-	if c.Scales.Shadow != nil {
-		tokens.Scales["shadow"] = copyStringMap(c.Scales.Shadow)
-	}
-	if c.Scales.ZIndex != nil {
-		tokens.Scales["z"] = copyStringMap(c.Scales.ZIndex)
-	}
-	if c.Scales.Opacity != nil {
-		tokens.Scales["opacity"] = copyStringMap(c.Scales.Opacity)
-	}
-	if c.Scales.Aspect != nil {
-		tokens.Scales["aspect"] = copyStringMap(c.Scales.Aspect)
-	}
-	if c.Scales.Duration != nil {
-		tokens.Scales["duration"] = copyStringMap(c.Scales.Duration)
-	}
-	if c.Scales.Easing != nil {
-		tokens.Scales["easing"] = copyStringMap(c.Scales.Easing)
-	}
-	if c.Scales.Delay != nil {
-		tokens.Scales["delay"] = copyStringMap(c.Scales.Delay)
-	}
-	if c.Scales.Translate != nil {
-		tokens.Scales["translate"] = copyStringMap(c.Scales.Translate)
-	}
-	if c.Scales.Rotate != nil {
-		tokens.Scales["rotate"] = copyStringMap(c.Scales.Rotate)
-	}
-	if c.Scales.Scale != nil {
-		tokens.Scales["scale"] = copyStringMap(c.Scales.Scale)
-	}
-	if c.Scales.MaxWidth != nil {
-		tokens.Scales["maxWidth"] = copyStringMap(c.Scales.MaxWidth)
-	}
-	if c.Scales.MaxHeight != nil {
-		tokens.Scales["maxHeight"] = copyStringMap(c.Scales.MaxHeight)
-	}
-	if c.Scales.Container != nil {
-		tokens.Scales["container"] = copyStringMap(c.Scales.Container)
+	for name, values := range c.Scales.byName() {
+		fluids := c.Scales.Fluid[name]
+		if values == nil && fluids == nil {
+			continue
+		}
+		scale := copyStringMap(values)
+		if scale == nil {
+			scale = make(map[string]string, len(fluids))
+		}
+		for key, fluid := range fluids {
+			// Validate rejects fluid values that cannot be clamped.
+			if clamp, err := fluid.Clamp(c.Breakpoints); err == nil {
+				scale[key] = clamp
+			}
+		}
+		tokens.Scales[name] = scale
 	}
 
 	return tokens
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultFluidMinViewport = "320px"
+	defaultFluidMaxViewport = "1280px"
+	rootFontSizePx          = 16
+)
+
+var lengthPattern = regexp.MustCompile(`^(-?\d*\.?\d+)(px|rem|em)?$`)
+
+// FluidValue is a scale entry written as a {min, max} object. It stays typed
+// from Load through NormalizeTokens, where it resolves to a clamp() value.
+type FluidValue struct {
+	Min         string `json:"min"`
+	Max         string `json:"max"`
+	MinViewport string `json:"minViewport,omitempty"`
+	MaxViewport string `json:"maxViewport,omitempty"`
+}
+
+func (f FluidValue) Clamp(breakpoints map[string]string) (string, error) {
+	minValue, unit, err := parseLength(f.Min)
+	if err != nil {
+		return "", fmt.Errorf("min: %w", err)
+	}
+	maxValue, maxUnit, err := parseLength(f.Max)
+	if err != nil {
+		return "", fmt.Errorf("max: %w", err)
+	}
+	if unit == "" {
+		unit = maxUnit
+	}
+	if maxUnit == "" {
+		maxUnit = unit
+	}
+	if unit != maxUnit {
+		return "", fmt.Errorf("min and max must use the same unit (got %s and %s)", f.Min, f.Max)
+	}
+	if unit == "em" {
+		return "", fmt.Errorf("em units cannot be interpolated against the viewport; use px or rem")
+	}
+
+	minViewport, maxViewport := f.MinViewport, f.MaxViewport
+	defaultMin, defaultMax := viewportBounds(breakpoints)
+	if minViewport == "" {
+		minViewport = defaultMin
+	}
+	if maxViewport == "" {
+		maxViewport = defaultMax
+	}
+	vwMin, err := viewportPx(minViewport, breakpoints)
+	if err != nil {
+		return "", fmt.Errorf("minViewport: %w", err)
+	}
+	vwMax, err := viewportPx(maxViewport, breakpoints)
+	if err != nil {
+		return "", fmt.Errorf("maxViewport: %w", err)
+	}
+	if vwMax <= vwMin {
+		return "", fmt.Errorf("maxViewport must be wider than minViewport")
+	}
+	if unit == "rem" {
+		vwMin /= rootFontSizePx
+		vwMax /= rootFontSizePx
+	}
+
+	slope := (maxValue - minValue) / (vwMax - vwMin)
+	intercept := minValue - slope*vwMin
+	lower, upper := f.Min, f.Max
+	if minValue > maxValue {
+		lower, upper = f.Max, f.Min
+	}
+	sign := "+"
+	if slope < 0 {
+		sign = "-"
+	}
+	return fmt.Sprintf("clamp(%s, %s%s %s %svw, %s)", lower, formatNumber(intercept), unit, sign, formatNumber(math.Abs(slope*100)), upper), nil
+}
+
+// extractFluidScales removes {min, max} objects from the merged scales and
+// returns them keyed by scale and entry name.
+func extractFluidScales(merged map[string]any) (map[string]map[string]FluidValue, error) {
+	scales, ok := merged["scales"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	var fluids map[string]map[string]FluidValue
+	for scaleName, rawScale := range scales {
+		scale, ok := rawScale.(map[string]any)
+		if !ok {
+			continue
+		}
+		for key, rawValue := range scale {
+			if _, ok := rawValue.(map[string]any); !ok {
+				continue
+			}
+			data, err := json.Marshal(rawValue)
+			if err != nil {
+				return nil, err
+			}
+			var fluid FluidValue
+			if err := json.Unmarshal(data, &fluid); err != nil {
+				return nil, fmt.Errorf("scales.%s.%s: %w", scaleName, key, err)
+			}
+			if fluid.Min == "" || fluid.Max == "" {
+				return nil, fmt.Errorf("scales.%s.%s: fluid values require min and max", scaleName, key)
+			}
+			if fluids == nil {
+				fluids = map[string]map[string]FluidValue{}
+			}
+			if fluids[scaleName] == nil {
+				fluids[scaleName] = map[string]FluidValue{}
+			}
+			fluids[scaleName][key] = fluid
+			delete(scale, key)
+		}
+	}
+	return fluids, nil
+}
+
+func validateFluid(c Config) error {
+	plain := c.Scales.byName()
+	for _, name := range slices.Sorted(maps.Keys(plain)) {
+		values := plain[name]
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			if strings.HasPrefix(strings.TrimSpace(values[key]), "fluid(") {
+				return fmt.Errorf("scales.%s.%s: %q is not a scale value; write fluid values as {\"min\": ..., \"max\": ...}", name, key, values[key])
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Scales.Fluid)) {
+		values := c.Scales.Fluid[name]
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			if _, err := values[key].Clamp(c.Breakpoints); err != nil {
+				return fmt.Errorf("scales.%s.%s: %w", name, key, err)
+			}
+		}
+	}
+	return nil
+}
+
+func viewportBounds(breakpoints map[string]string) (string, string) {
+	minName, maxName := "", ""
+	minPx, maxPx := 0.0, 0.0
+	for name, value := range breakpoints {
+		px, err := viewportPx(value, nil)
+		if err != nil {
+			continue
+		}
+		if minName == "" || px < minPx || px == minPx && name < minName {
+			minName, minPx = name, px
+		}
+		if maxName == "" || px > maxPx || px == maxPx && name < maxName {
+			maxName, maxPx = name, px
+		}
+	}
+	if minName == "" || minName == maxName {
+		return defaultFluidMinViewport, defaultFluidMaxViewport
+	}
+	return minName, maxName
+}
+
+func viewportPx(value string, breakpoints map[string]string) (float64, error) {
+	if breakpoint, ok := breakpoints[value]; ok {
+		value = breakpoint
+	}
+	number, unit, err := parseLength(value)
+	if err != nil {
+		return 0, err
+	}
+	switch unit {
+	case "px":
+		return number, nil
+	case "rem", "em":
+		return number * rootFontSizePx, nil
+	default:
+		return 0, fmt.Errorf("viewport bound %q must be a breakpoint name or a px/rem/em length", value)
+	}
+}
+
+func parseLength(value string) (float64, string, error) {
+	match := lengthPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, "", fmt.Errorf("invalid length %q (use px, rem, or em)", value)
+	}
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid length %q: %w", value, err)
+	}
+	if match[2] == "" && number != 0 {
+		return 0, "", fmt.Errorf("length %q requires a unit", value)
+	}
+	return number, match[2], nil
+}
+
+func formatNumber(value float64) string {
+	formatted := strconv.FormatFloat(value, 'f', 4, 64)
+	formatted = strings.TrimRight(formatted, "0")
+	formatted = strings.TrimSuffix(formatted, ".")
+	if formatted == "" || formatted == "-" {
+		return "0"
+	}
+	return formatted
+}
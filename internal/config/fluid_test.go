@@ -0,0 +1,143 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFluidValueClamp(t *testing.T) {
+	breakpoints := map[string]string{"sm": "640px", "md": "768px", "xl": "1280px"}
+	tests := []struct {
+		name        string
+		fluid       FluidValue
+		breakpoints map[string]string
+		want        string
+		err         string
+	}{
+		{
+			name:        "rem between smallest and largest breakpoints",
+			fluid:       FluidValue{Min: "2rem", Max: "4rem"},
+			breakpoints: breakpoints,
+			want:        "clamp(2rem, 0rem + 5vw, 4rem)",
+		},
+		{
+			name:        "px with intercept",
+			fluid:       FluidValue{Min: "16px", Max: "24px", MinViewport: "400px", MaxViewport: "1200px"},
+			breakpoints: breakpoints,
+			want:        "clamp(16px, 12px + 1vw, 24px)",
+		},
+		{
+			name:        "negative slope",
+			fluid:       FluidValue{Min: "4rem", Max: "2rem"},
+			breakpoints: breakpoints,
+			want:        "clamp(2rem, 6rem - 5vw, 4rem)",
+		},
+		{
+			name:        "named viewport bounds",
+			fluid:       FluidValue{Min: "1rem", Max: "2rem", MinViewport: "md", MaxViewport: "xl"},
+			breakpoints: breakpoints,
+			want:        "clamp(1rem, -0.5rem + 3.125vw, 2rem)",
+		},
+		{
+			name:        "one missing bound uses the default",
+			fluid:       FluidValue{Min: "1rem", Max: "2rem", MinViewport: "md"},
+			breakpoints: breakpoints,
+			want:        "clamp(1rem, -0.5rem + 3.125vw, 2rem)",
+		},
+		{
+			name:  "default viewport without breakpoints",
+			fluid: FluidValue{Min: "1rem", Max: "3rem"},
+			want:  "clamp(1rem, 0.3333rem + 3.3333vw, 3rem)",
+		},
+		{
+			name:        "mismatched units",
+			fluid:       FluidValue{Min: "2rem", Max: "40px"},
+			breakpoints: breakpoints,
+			err:         "min and max must use the same unit",
+		},
+		{
+			name:        "em units",
+			fluid:       FluidValue{Min: "1em", Max: "2em"},
+			breakpoints: breakpoints,
+			err:         "em units cannot be interpolated",
+		},
+		{
+			name:        "inverted viewport bounds",
+			fluid:       FluidValue{Min: "1rem", Max: "2rem", MinViewport: "xl", MaxViewport: "sm"},
+			breakpoints: breakpoints,
+			err:         "maxViewport must be wider than minViewport",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fluid.Clamp(tt.breakpoints)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Clamp() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Clamp() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Clamp() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadKeepsFluidValuesTyped(t *testing.T) {
+	site := writeSiteConfig(t, `{"scales": {"fontSize": {"hero": {"min": "2rem", "max": "4rem"}}}}`)
+	cfg, err := Load("", site)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if got := cfg.Scales.Fluid["fontSize"]["hero"]; got != (FluidValue{Min: "2rem", Max: "4rem"}) {
+		t.Errorf("Scales.Fluid[fontSize][hero] = %+v", got)
+	}
+	if _, ok := cfg.Scales.FontSize["hero"]; ok {
+		t.Errorf("fluid entry leaked into the plain fontSize scale")
+	}
+
+	canonical := cfg.Canonicalize()
+	if got := canonical.Tokens.Scales["fontSize"]["hero"]; !strings.HasPrefix(got, "clamp(2rem, ") {
+		t.Errorf("Tokens.Scales[fontSize][hero] = %q, want a clamp() value", got)
+	}
+
+	data, err := MarshalDeterministic(canonical.Config)
+	if err != nil {
+		t.Fatalf("MarshalDeterministic() error = %v", err)
+	}
+	if !strings.Contains(string(data), "\"hero\": {\n        \"max\": \"4rem\",\n        \"min\": \"2rem\"\n      }") {
+		t.Errorf("printed config does not keep the fluid object:\n%s", data)
+	}
+}
+
+func TestValidateRejectsRawFluidStrings(t *testing.T) {
+	site := writeSiteConfig(t, `{"scales": {"fontSize": {"hero": "fluid(2rem, 4rem)"}}}`)
+	cfg, err := Load("", site)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "scales.fontSize.hero") {
+		t.Fatalf("Validate() error = %v, want a scales.fontSize.hero error", err)
+	}
+}
+
+func writeSiteConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "site.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write site config: %v", err)
+	}
+	return path
+}
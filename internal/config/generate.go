@@ -0,0 +1,114 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type ScaleGenerator struct {
+	Base     string    `json:"base"`
+	Steps    []float64 `json:"steps,omitempty"`
+	Ratio    float64   `json:"ratio,omitempty"`
+	Names    []string  `json:"names,omitempty"`
+	BaseName string    `json:"baseName,omitempty"`
+}
+
+func (g ScaleGenerator) Expand() (map[string]string, error) {
+	base, unit, err := parseLength(g.Base)
+	if err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+
+	out := map[string]string{}
+	switch {
+	case len(g.Steps) > 0 && g.Ratio != 0:
+		return nil, errors.New("use either steps or ratio, not both")
+	case len(g.Steps) > 0:
+		for _, step := range g.Steps {
+			// Keys become class suffixes and token names, which only allow
+			// whole numbers (the space scale writes 0.5rem as "2", not "0.5").
+			if step < 0 || step != math.Trunc(step) {
+				return nil, fmt.Errorf("step %s must be a non-negative whole number", formatNumber(step))
+			}
+			out[formatNumber(step)] = formatLength(base*step, unit)
+		}
+	case g.Ratio != 0:
+		if g.Ratio <= 0 {
+			return nil, errors.New("ratio must be greater than zero")
+		}
+		if len(g.Names) == 0 {
+			return nil, errors.New("names are required with ratio")
+		}
+		origin := 0
+		baseName := g.BaseName
+		if baseName == "" {
+			baseName = "base"
+		}
+		for i, name := range g.Names {
+			if name == baseName {
+				origin = i
+			}
+		}
+		if g.BaseName != "" && g.Names[origin] != g.BaseName {
+			return nil, fmt.Errorf("baseName %q is not listed in names", g.BaseName)
+		}
+		for i, name := range g.Names {
+			out[name] = formatLength(base*math.Pow(g.Ratio, float64(i-origin)), unit)
+		}
+	default:
+		return nil, errors.New("steps or ratio is required")
+	}
+	return out, nil
+}
+
+func expandScaleGenerators(raw map[string]any) error {
+	scales, ok := raw["scales"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(scales))
+	for name := range scales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		scale, ok := scales[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		rawGenerator, ok := scale["generate"]
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(rawGenerator)
+		if err != nil {
+			return err
+		}
+		var generator ScaleGenerator
+		if err := json.Unmarshal(data, &generator); err != nil {
+			return fmt.Errorf("scales.%s.generate: %w", name, err)
+		}
+		generated, err := generator.Expand()
+		if err != nil {
+			return fmt.Errorf("scales.%s.generate: %w", name, err)
+		}
+		delete(scale, "generate")
+		for key, value := range generated {
+			if _, ok := scale[key]; ok {
+				continue
+			}
+			scale[key] = value
+		}
+	}
+	return nil
+}
+
+func formatLength(value float64, unit string) string {
+	if value == 0 {
+		return "0"
+	}
+	return formatNumber(value) + unit
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestScaleGeneratorExpand(t *testing.T) {
+	tests := []struct {
+		name      string
+		generator ScaleGenerator
+		want      map[string]string
+		err       string
+	}{
+		{
+			name:      "linear steps",
+			generator: ScaleGenerator{Base: "0.25rem", Steps: []float64{0, 1, 2, 6}},
+			want:      map[string]string{"0": "0", "1": "0.25rem", "2": "0.5rem", "6": "1.5rem"},
+		},
+		{
+			name:      "modular origin at base",
+			generator: ScaleGenerator{Base: "1rem", Ratio: 1.25, Names: []string{"sm", "base", "lg", "xl"}},
+			want:      map[string]string{"sm": "0.8rem", "base": "1rem", "lg": "1.25rem", "xl": "1.5625rem"},
+		},
+		{
+			name:      "modular origin at baseName",
+			generator: ScaleGenerator{Base: "16px", Ratio: 2, Names: []string{"xs", "sm", "md"}, BaseName: "md"},
+			want:      map[string]string{"xs": "4px", "sm": "8px", "md": "16px"},
+		},
+		{
+			name:      "modular origin defaults to the first name",
+			generator: ScaleGenerator{Base: "1rem", Ratio: 2, Names: []string{"a", "b", "c"}},
+			want:      map[string]string{"a": "1rem", "b": "2rem", "c": "4rem"},
+		},
+		{
+			name:      "fractional step",
+			generator: ScaleGenerator{Base: "0.25rem", Steps: []float64{0.5, 1}},
+			err:       "step 0.5 must be a non-negative whole number",
+		},
+		{
+			name:      "negative step",
+			generator: ScaleGenerator{Base: "0.25rem", Steps: []float64{-1}},
+			err:       "step -1 must be a non-negative whole number",
+		},
+		{
+			name:      "steps and ratio",
+			generator: ScaleGenerator{Base: "1rem", Steps: []float64{1}, Ratio: 1.5},
+			err:       "use either steps or ratio",
+		},
+		{
+			name:      "ratio without names",
+			generator: ScaleGenerator{Base: "1rem", Ratio: 1.5},
+			err:       "names are required",
+		},
+		{
+			name:      "unknown baseName",
+			generator: ScaleGenerator{Base: "1rem", Ratio: 1.5, Names: []string{"sm", "lg"}, BaseName: "md"},
+			err:       `baseName "md" is not listed`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.generator.Expand()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expand() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expand() error = %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("Expand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandScaleGeneratorsKeepsExplicitKeys(t *testing.T) {
+	raw := map[string]any{
+		"scales": map[string]any{
+			"space": map[string]any{
+				"generate": map[string]any{"base": "0.25rem", "steps": []any{1.0, 2.0, 4.0}},
+				"2":        "0.4rem",
+				"px":       "1px",
+			},
+			"size": map[string]any{"sm": "24rem"},
+		},
+	}
+	if err := expandScaleGenerators(raw); err != nil {
+		t.Fatalf("expandScaleGenerators() error = %v", err)
+	}
+
+	scales := raw["scales"].(map[string]any)
+	want := map[string]any{"1": "0.25rem", "2": "0.4rem", "4": "1rem", "px": "1px"}
+	if got := scales["space"].(map[string]any); !maps.Equal(got, want) {
+		t.Errorf("space = %v, want %v", got, want)
+	}
+	if got := scales["size"].(map[string]any); !maps.Equal(got, map[string]any{"sm": "24rem"}) {
+		t.Errorf("size = %v, want it untouched", got)
+	}
+}
+
+func TestExpandScaleGeneratorsReportsScale(t *testing.T) {
+	raw := map[string]any{
+		"scales": map[string]any{
+			"space": map[string]any{
+				"generate": map[string]any{"base": "0.25rem", "steps": []any{0.5}},
+			},
+		},
+	}
+	err := expandScaleGenerators(raw)
+	if err == nil || !strings.HasPrefix(err.Error(), "scales.space.generate: ") {
+		t.Fatalf("expandScaleGenerators() error = %v, want a scales.space.generate error", err)
+	}
+}
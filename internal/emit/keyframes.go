@@ -0,0 +1,85 @@
+package emit
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"lcss/internal/config"
+)
+
+func KeyframesCSS(cfg config.Config, names []string) []byte {
+	if len(cfg.Keyframes) == 0 || len(names) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	for _, name := range names {
+		keyframe, ok := cfg.Keyframes[name]
+		if !ok || len(keyframe) == 0 {
+			continue
+		}
+		b.WriteString("@keyframes ")
+		b.WriteString(name)
+		b.WriteString(" {\n")
+		for _, step := range sortedSteps(keyframe) {
+			b.WriteString("  ")
+			b.WriteString(step)
+			b.WriteString(" {\n")
+			decls := keyframe[step]
+			properties := make([]string, 0, len(decls))
+			for property := range decls {
+				properties = append(properties, property)
+			}
+			sort.Strings(properties)
+			for _, property := range properties {
+				b.WriteString("    ")
+				b.WriteString(property)
+				b.WriteString(": ")
+				b.WriteString(ResolveTokenRefs(decls[property]))
+				b.WriteString(";\n")
+			}
+			b.WriteString("  }\n")
+		}
+		b.WriteString("}\n")
+	}
+
+	return []byte(b.String())
+}
+
+func ResolveTokenRefs(value string) string {
+	return config.TokenRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		match := config.TokenRefPattern.FindStringSubmatch(ref)
+		return "var(--" + scalePrefix(match[1]) + "-" + match[2] + ")"
+	})
+}
+
+func sortedSteps(keyframe config.Keyframe) []string {
+	steps := make([]string, 0, len(keyframe))
+	for step := range keyframe {
+		steps = append(steps, step)
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		left, right := stepOffset(steps[i]), stepOffset(steps[j])
+		if left == right {
+			return steps[i] < steps[j]
+		}
+		return left < right
+	})
+	return steps
+}
+
+func stepOffset(step string) float64 {
+	first := strings.TrimSpace(strings.Split(step, ",")[0])
+	switch first {
+	case "from":
+		return 0
+	case "to":
+		return 100
+	}
+	value, err := strconv.ParseFloat(strings.TrimSuffix(first, "%"), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
@@ -78,6 +78,12 @@ func appendScaleTokens(entries []tokenEntry, scales map[string]map[string]string
 		"maxWidth",
 		"maxHeight",
 		"container",
+		"gradientStop",
+		"blur",
+		"brightness",
+		"dropShadow",
+		"ring",
+		"animation",
 	}
 	for _, scale := range scaleOrder {
 		values, ok := scales[scale]
@@ -130,6 +136,10 @@ func scalePrefix(scale string) string {
 		return "max-width"
 	case "maxHeight":
 		return "max-height"
+	case "gradientStop":
+		return "gradient-stop"
+	case "dropShadow":
+		return "drop-shadow"
 	default:
 		return scale
 	}
@@ -0,0 +1,140 @@
+package extract
+
+import (
+	"strconv"
+	"strings"
+)
+
+const maxBraceExpansion = 4096
+
+func ExpandBraces(pattern string) []string {
+	open, close := -1, -1
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			if depth == 0 {
+				open = i
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth == 0 {
+				close = i
+			}
+		}
+		if close >= 0 {
+			break
+		}
+	}
+	if open < 0 || close < 0 {
+		return []string{pattern}
+	}
+
+	prefix, body, suffix := pattern[:open], pattern[open+1:close], pattern[close+1:]
+	alternatives := braceAlternatives(body)
+	if alternatives == nil {
+		return []string{pattern}
+	}
+
+	var expanded []string
+	for _, alternative := range alternatives {
+		for _, rest := range ExpandBraces(prefix + alternative + suffix) {
+			expanded = append(expanded, rest)
+			if len(expanded) >= maxBraceExpansion {
+				return expanded
+			}
+		}
+	}
+	return expanded
+}
+
+func braceAlternatives(body string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(body[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, strings.TrimSpace(body[start:]))
+	if len(parts) > 1 {
+		return parts
+	}
+	return braceRange(body)
+}
+
+func braceRange(body string) []string {
+	bounds := strings.Split(body, "..")
+	if len(bounds) < 2 || len(bounds) > 3 {
+		return nil
+	}
+	numbers := make([]int, len(bounds))
+	for i, bound := range bounds {
+		value, err := strconv.Atoi(strings.TrimSpace(bound))
+		if err != nil {
+			return nil
+		}
+		numbers[i] = value
+	}
+	step := 1
+	if len(numbers) == 3 {
+		step = numbers[2]
+	}
+	if step < 0 {
+		step = -step
+	}
+	if step == 0 {
+		return nil
+	}
+	from, to := numbers[0], numbers[1]
+	var values []string
+	if from <= to {
+		for value := from; value <= to && len(values) < maxBraceExpansion; value += step {
+			values = append(values, strconv.Itoa(value))
+		}
+	} else {
+		for value := from; value >= to && len(values) < maxBraceExpansion; value -= step {
+			values = append(values, strconv.Itoa(value))
+		}
+	}
+	return values
+}
+
+func splitOutsideBraces(value string) []string {
+	var fields []string
+	var current strings.Builder
+	depth := 0
+	for _, r := range value {
+		switch {
+		case r == '{':
+			depth++
+		case r == '}' && depth > 0:
+			depth--
+		case depth == 0 && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+			continue
+		case depth > 0 && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
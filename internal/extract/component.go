@@ -0,0 +1,43 @@
+package extract
+
+import "regexp"
+
+const (
+	VueExtractor    = "vue"
+	SvelteExtractor = "svelte"
+	AstroExtractor  = "astro"
+)
+
+var (
+	componentExprPattern  = regexp.MustCompile(`\bclass(?::list)?\s*=\s*\{`)
+	classDirectivePattern = regexp.MustCompile(`\bclass:([^\s=/>{}"']+)`)
+)
+
+func extractComponentClasses(content string) []string {
+	var classes []string
+	for _, pattern := range []*regexp.Regexp{classAttrPattern, classNameAttrPattern} {
+		for _, value := range attrValues(pattern, content) {
+			classes = append(classes, mustacheClasses(value)...)
+		}
+	}
+	classes = append(classes, extractBindingClasses(content)...)
+
+	for _, match := range componentExprPattern.FindAllStringIndex(content, -1) {
+		if isBoundAttr(content, match[0]) {
+			continue
+		}
+		open := match[1] - 1
+		end := matchingClose(content, open)
+		classes = append(classes, expressionClasses(content[open+1:max(open+1, end-1)])...)
+	}
+	for _, match := range classDirectivePattern.FindAllStringSubmatch(content, -1) {
+		if match[1] != "list" {
+			classes = append(classes, match[1])
+		}
+	}
+	return classes
+}
+
+func mustacheClasses(value string) []string {
+	return interpolatedClasses(value, "{")
+}
@@ -0,0 +1,228 @@
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const DataExtractor = "data"
+
+var DefaultDataSelectors = []string{"$..class", "$..className"}
+
+type selectorStep struct {
+	name      string
+	index     int
+	wildcard  bool
+	recursive bool
+}
+
+type selector []selectorStep
+
+type dataExtractor struct {
+	selectors []selector
+}
+
+func newDataExtractor(selectors []string) dataExtractor {
+	if len(selectors) == 0 {
+		selectors = DefaultDataSelectors
+	}
+	parsed, _ := parseSelectors(selectors)
+	return dataExtractor{selectors: parsed}
+}
+
+func (e dataExtractor) Extract(content string) ([]string, error) {
+	roots, err := decodeData(content)
+	if err != nil {
+		return nil, err
+	}
+	var classes []string
+	for _, root := range roots {
+		for _, sel := range e.selectors {
+			for _, value := range sel.selectValues(root) {
+				classes = append(classes, dataValueClasses(value)...)
+			}
+		}
+	}
+	return classes, nil
+}
+
+// decodeData returns one root per document: JSON files have one, YAML files
+// have one per --- separated document.
+func decodeData(content string) ([]any, error) {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var root any
+		if err := json.Unmarshal([]byte(trimmed), &root); err != nil {
+			return nil, fmt.Errorf("decode json: %w", err)
+		}
+		return []any{root}, nil
+	}
+	roots, err := decodeYAML(content)
+	if err != nil {
+		return nil, fmt.Errorf("decode yaml: %w", err)
+	}
+	return roots, nil
+}
+
+func dataValueClasses(value any) []string {
+	switch value := value.(type) {
+	case string:
+		return splitClasses(value)
+	case []any:
+		var classes []string
+		for _, item := range value {
+			if text, ok := item.(string); ok {
+				classes = append(classes, splitClasses(text)...)
+			}
+		}
+		return classes
+	}
+	return nil
+}
+
+func parseSelectors(values []string) ([]selector, error) {
+	selectors := make([]selector, 0, len(values))
+	for _, value := range values {
+		sel, err := parseSelector(value)
+		if err != nil {
+			return nil, fmt.Errorf("build.dataSelectors: %q: %w", value, err)
+		}
+		selectors = append(selectors, sel)
+	}
+	return selectors, nil
+}
+
+func parseSelector(value string) (selector, error) {
+	rest := strings.TrimSpace(value)
+	if !strings.HasPrefix(rest, "$") {
+		return nil, fmt.Errorf("selector must start with $")
+	}
+	rest = rest[1:]
+	var sel selector
+	for rest != "" {
+		var step selectorStep
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			step.recursive = true
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+		case strings.HasPrefix(rest, "["):
+		default:
+			return nil, fmt.Errorf("unexpected %q", rest)
+		}
+
+		if strings.HasPrefix(rest, "[") {
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [")
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				step.wildcard = true
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				step.name = inner[1 : len(inner)-1]
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid index %q", inner)
+				}
+				step.index = index
+			}
+			sel = append(sel, step)
+			continue
+		}
+
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		name := rest[:end]
+		rest = rest[end:]
+		if name == "" {
+			return nil, fmt.Errorf("empty name")
+		}
+		if name == "*" {
+			step.wildcard = true
+		} else {
+			step.name = name
+		}
+		sel = append(sel, step)
+	}
+	return sel, nil
+}
+
+func (sel selector) selectValues(root any) []any {
+	nodes := []any{root}
+	for _, step := range sel {
+		var next []any
+		for _, node := range nodes {
+			candidates := []any{node}
+			if step.recursive {
+				candidates = descendants(node)
+			}
+			for _, candidate := range candidates {
+				next = append(next, step.children(candidate)...)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func (step selectorStep) children(node any) []any {
+	switch node := node.(type) {
+	case map[string]any:
+		if step.wildcard {
+			keys := make([]string, 0, len(node))
+			for key := range node {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			values := make([]any, 0, len(keys))
+			for _, key := range keys {
+				values = append(values, node[key])
+			}
+			return values
+		}
+		if value, ok := node[step.name]; ok && step.name != "" {
+			return []any{value}
+		}
+	case []any:
+		if step.wildcard {
+			return node
+		}
+		if step.name == "" && step.index < len(node) {
+			return []any{node[step.index]}
+		}
+	}
+	return nil
+}
+
+func descendants(node any) []any {
+	nodes := []any{node}
+	switch node := node.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(node))
+		for key := range node {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			nodes = append(nodes, descendants(node[key])...)
+		}
+	case []any:
+		for _, item := range node {
+			nodes = append(nodes, descendants(item)...)
+		}
+	}
+	return nodes
+}
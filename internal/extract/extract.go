@@ -5,8 +5,10 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
 )
 
 var (
@@ -14,46 +16,66 @@ var (
 	classNameAttrPattern = regexp.MustCompile(`(?s)\bclassName\s*=\s*(?:"([^"]*)"|'([^']*)')`)
 	classActionPattern   = regexp.MustCompile(`(?s)\bclass\s*=\s*{{(.*?)}}`)
 	classNameAction      = regexp.MustCompile(`(?s)\bclassName\s*=\s*{{(.*?)}}`)
+	bindingAttrPattern   = regexp.MustCompile(`(?s)(?:\bv-bind|\bx-bind)?:(?:class|className)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
 	stringLiteralPattern = regexp.MustCompile(`"(?:\\.|[^"\\])*"|` + "`" + `[^` + "`" + `]*` + "`")
 	validClassPattern    = regexp.MustCompile(`^[a-zA-Z0-9-:_/%]+$`)
 )
 
+var (
+	safelistCommentPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?s)<!--\s*(?:lcss|lattice-safelist):(.*?)-->`),
+		regexp.MustCompile(`(?s)/\*\s*(?:lcss|lattice-safelist):(.*?)\*/`),
+		regexp.MustCompile(`(?m)//\s*(?:lcss|lattice-safelist):(.*)$`),
+	}
+	hashSafelistCommentPattern = regexp.MustCompile(`(?m)(?:^|\s)#\s*(?:lcss|lattice-safelist):(.*)$`)
+	hashCommentExtensions      = map[string]struct{}{
+		".yaml": {}, ".yml": {}, ".toml": {}, ".sh": {}, ".bash": {}, ".zsh": {},
+	}
+)
+
 type Result struct {
-	Classes []string
-	Counts  map[string]int
-	ByFile  map[string]map[string]int
-	Files   int
+	Classes    []string
+	Counts     map[string]int
+	ByFile     map[string]map[string]int
+	Extractors map[string]string
+	Files      int
+	Warnings   []string
 }
 
-func FromPaths(patterns []string, safelist []string) (Result, error) {
+func FromPaths(patterns []string, safelist []string, options Options) (Result, error) {
+	extractors, err := newExtractorSet(options)
+	if err != nil {
+		return Result{}, err
+	}
+
 	files, err := expandPatterns(patterns)
 	if err != nil {
 		return Result{}, err
 	}
 
+	scanned, err := scanFiles(files, extractors, options.Workers)
+	if err != nil {
+		return Result{}, err
+	}
+
 	classSet := map[string]struct{}{}
 	counts := map[string]int{}
 	byFile := map[string]map[string]int{}
+	byExtractor := map[string]string{}
 
-	for _, file := range files {
-		data, err := os.ReadFile(file)
-		if err != nil {
-			return Result{}, fmt.Errorf("read %s: %w", file, err)
+	var warnings []string
+	for i, file := range files {
+		if scanned[i].warning != "" {
+			warnings = append(warnings, scanned[i].warning)
 		}
-
-		fileCounts := map[string]int{}
-
-		for _, class := range extractClasses(string(data)) {
-			if !validClassPattern.MatchString(class) {
-				continue
-			}
-			counts[class]++
+		fileCounts := scanned[i].counts
+		for class, count := range fileCounts {
+			counts[class] += count
 			classSet[class] = struct{}{}
-			fileCounts[class]++
 		}
-
 		if len(fileCounts) > 0 {
 			byFile[file] = fileCounts
+			byExtractor[file] = scanned[i].extractor
 		}
 	}
 
@@ -73,28 +95,106 @@ func FromPaths(patterns []string, safelist []string) (Result, error) {
 	sort.Strings(classes)
 
 	return Result{
-		Classes: classes,
-		Counts:  counts,
-		ByFile:  byFile,
-		Files:   len(files),
+		Classes:    classes,
+		Counts:     counts,
+		ByFile:     byFile,
+		Extractors: byExtractor,
+		Files:      len(files),
+		Warnings:   warnings,
 	}, nil
 }
 
+type fileScan struct {
+	extractor string
+	counts    map[string]int
+	warning   string
+	err       error
+}
+
+func scanFiles(files []string, extractors extractorSet, workers int) ([]fileScan, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(files))
+
+	scanned := make([]fileScan, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				scanned[i] = scanFile(files[i], extractors)
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, scan := range scanned {
+		if scan.err != nil {
+			return nil, scan.err
+		}
+	}
+	return scanned, nil
+}
+
+func scanFile(file string, extractors extractorSet) fileScan {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fileScan{err: fmt.Errorf("read %s: %w", file, err)}
+	}
+
+	name, extractor := extractors.forFile(file)
+	warning := ""
+	extracted, err := extractor.Extract(string(data))
+	if err != nil {
+		warning = fmt.Sprintf("skipped %s (%s): %v", file, name, err)
+		extracted = nil
+	}
+	extracted = append(extracted, extractSafelistComments(file, name, string(data))...)
+
+	counts := map[string]int{}
+	for _, class := range extracted {
+		if validClassPattern.MatchString(class) {
+			counts[class]++
+		}
+	}
+	return fileScan{extractor: name, counts: counts, warning: warning}
+}
+
 func FilesFromPatterns(patterns []string) ([]string, error) {
 	return expandPatterns(patterns)
 }
 
 func expandPatterns(patterns []string) ([]string, error) {
-	seen := map[string]struct{}{}
-	var files []string
-
+	var includes, excludes []string
 	for _, pattern := range patterns {
 		pattern = strings.TrimSpace(pattern)
+		negate := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimSpace(strings.TrimPrefix(pattern, "!"))
 		if pattern == "" {
 			continue
 		}
+		for _, expanded := range ExpandBraces(pattern) {
+			if negate {
+				excludes = append(excludes, filepath.ToSlash(filepath.Clean(expanded)))
+			} else {
+				includes = append(includes, expanded)
+			}
+		}
+	}
+
+	filter := contentFilter{excludes: excludes, ignore: newIgnoreMatcher()}
+	seen := map[string]struct{}{}
+	var files []string
 
-		matches, err := globPattern(pattern)
+	for _, pattern := range includes {
+		matches, err := globPattern(pattern, filter)
 		if err != nil {
 			return nil, fmt.Errorf("glob %s: %w", pattern, err)
 		}
@@ -118,16 +218,67 @@ func expandPatterns(patterns []string) ([]string, error) {
 	return files, nil
 }
 
-func globPattern(pattern string) ([]string, error) {
-	if !strings.Contains(pattern, "**") {
-		return filepath.Glob(pattern)
+type contentFilter struct {
+	excludes []string
+	ignore   *ignoreMatcher
+}
+
+func (f contentFilter) excluded(path string) bool {
+	path = filepath.ToSlash(filepath.Clean(path))
+	for _, exclude := range f.excludes {
+		if globMatch(exclude, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f contentFilter) skipEntry(path string, isDir bool) bool {
+	if isDir && filepath.Base(path) == ".git" {
+		return true
+	}
+	return f.excluded(path) || f.ignore.ignored(path, isDir)
+}
+
+func (f contentFilter) skipMatch(root, path string) bool {
+	if f.excluded(path) {
+		return true
 	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	current := filepath.Clean(root)
+	segments := strings.Split(rel, string(filepath.Separator))
+	for i, segment := range segments {
+		current = filepath.Join(current, segment)
+		if f.skipEntry(current, i < len(segments)-1) {
+			return true
+		}
+	}
+	return false
+}
 
+func globPattern(pattern string, filter contentFilter) ([]string, error) {
 	root, remaining := splitGlob(pattern)
 	if root == "" {
 		root = "."
 	}
 
+	if !strings.Contains(pattern, "**") {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		kept := matches[:0]
+		for _, match := range matches {
+			if !filter.skipMatch(root, match) {
+				kept = append(kept, match)
+			}
+		}
+		return kept, nil
+	}
+
 	rootInfo, err := os.Stat(root)
 	if err != nil {
 		return nil, err
@@ -141,6 +292,15 @@ func globPattern(pattern string) ([]string, error) {
 		if walkErr != nil {
 			return walkErr
 		}
+		if path == root {
+			return nil
+		}
+		if filter.skipEntry(path, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		if entry.IsDir() {
 			return nil
 		}
@@ -237,20 +397,68 @@ func extractClasses(content string) []string {
 
 	classes = append(classes, extractAttrClasses(classAttrPattern, content)...)
 	classes = append(classes, extractAttrClasses(classNameAttrPattern, content)...)
+	classes = append(classes, extractBindingClasses(content)...)
 	classes = append(classes, extractActionClasses(classActionPattern, content)...)
 	classes = append(classes, extractActionClasses(classNameAction, content)...)
 
 	return classes
 }
 
+func extractSafelistComments(file, extractor, content string) []string {
+	if extractor == MarkdownExtractor {
+		content = stripMarkdownCode(content)
+	}
+	patterns := safelistCommentPatterns
+	if _, ok := hashCommentExtensions[normalizeExtension(filepath.Ext(file))]; ok {
+		patterns = append(patterns[:len(patterns):len(patterns)], hashSafelistCommentPattern)
+	}
+
+	var classes []string
+	for _, pattern := range patterns {
+		for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+			for _, field := range splitOutsideBraces(match[1]) {
+				classes = append(classes, ExpandBraces(field)...)
+			}
+		}
+	}
+	return classes
+}
+
 func extractAttrClasses(pattern *regexp.Regexp, content string) []string {
 	var classes []string
-	for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+	for _, value := range attrValues(pattern, content) {
+		classes = append(classes, splitClasses(value)...)
+	}
+	return classes
+}
+
+func attrValues(pattern *regexp.Regexp, content string) []string {
+	var values []string
+	for _, match := range pattern.FindAllStringSubmatchIndex(content, -1) {
+		if isBoundAttr(content, match[0]) {
+			continue
+		}
+		if match[2] >= 0 {
+			values = append(values, content[match[2]:match[3]])
+		} else if match[4] >= 0 {
+			values = append(values, content[match[4]:match[5]])
+		}
+	}
+	return values
+}
+
+func isBoundAttr(content string, start int) bool {
+	return start > 0 && content[start-1] == ':'
+}
+
+func extractBindingClasses(content string) []string {
+	var classes []string
+	for _, match := range bindingAttrPattern.FindAllStringSubmatch(content, -1) {
 		value := match[1]
 		if value == "" {
 			value = match[2]
 		}
-		classes = append(classes, splitClasses(value)...)
+		classes = append(classes, expressionClasses(value)...)
 	}
 	return classes
 }
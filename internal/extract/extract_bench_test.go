@@ -0,0 +1,82 @@
+package extract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeContentTree(tb testing.TB, dirs, filesPerDir int) string {
+	tb.Helper()
+	root := tb.TempDir()
+	templates := []struct{ ext, content string }{
+		{".html", `<div class="p-4 flex items-center" :class="{ 'bg-blue-500': active }">%d</div>` + "\n"},
+		{".tsx", `<div className={clsx("gap-4 grid", ok && "text-white")}>{%d}</div>` + "\n"},
+		{".vue", `<p class="text-sm" v-bind:class="[big ? 'text-xl' : 'text-base']">{{ n%d }}</p>` + "\n"},
+		{".md", `<section class="mt-8 rounded-lg">%d</section>` + "\n\n`class=\"ignored\"`\n"},
+	}
+	for d := range dirs {
+		dir := filepath.Join(root, fmt.Sprintf("d%d", d))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			tb.Fatal(err)
+		}
+		for f := range filesPerDir {
+			template := templates[(d+f)%len(templates)]
+			var content []byte
+			for line := range 50 {
+				content = fmt.Appendf(content, template.content, line)
+			}
+			content = fmt.Appendf(content, "<!-- lcss: w-%d -->\n", f%12)
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%d%s", f, template.ext)), content, 0o644); err != nil {
+				tb.Fatal(err)
+			}
+		}
+	}
+	return root
+}
+
+func TestFromPathsWorkersDeterministic(t *testing.T) {
+	root := writeContentTree(t, 8, 25)
+	patterns := []string{filepath.Join(root, "**", "*.{html,tsx,vue,md}")}
+	safelist := []string{"container"}
+
+	want, err := FromPaths(patterns, safelist, Options{Workers: 1})
+	if err != nil {
+		t.Fatalf("FromPaths(workers=1): %v", err)
+	}
+	if want.Files != 200 {
+		t.Fatalf("Files = %d, want 200", want.Files)
+	}
+	for _, workers := range []int{0, 2, 7, 64} {
+		got, err := FromPaths(patterns, safelist, Options{Workers: workers})
+		if err != nil {
+			t.Fatalf("FromPaths(workers=%d): %v", workers, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FromPaths(workers=%d) result differs from workers=1", workers)
+		}
+	}
+}
+
+func BenchmarkFromPaths(b *testing.B) {
+	root := writeContentTree(b, 20, 50)
+	patterns := []string{filepath.Join(root, "**", "*.{html,tsx,vue,md}")}
+
+	for _, bench := range []struct {
+		name    string
+		workers int
+	}{
+		{"workers=1", 1},
+		{"workers=default", 0},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for b.Loop() {
+				if _, err := FromPaths(patterns, nil, Options{Workers: bench.workers}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFromPathsFixtures(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		extractor string
+		classes   []string
+	}{
+		{
+			name:      "alpine bindings",
+			path:      "testdata/alpine/index.html",
+			extractor: MarkupExtractor,
+			classes:   []string{"absolute", "bg-blue-500", "bg-white", "block", "hidden", "mt-2", "px-4", "py-2", "relative", "shadow-md", "text-white"},
+		},
+		{
+			name:      "vue class bindings",
+			path:      "testdata/vue/Card.vue",
+			extractor: VueExtractor,
+			classes:   []string{"bg-blue-500", "font-bold", "hidden", "p-4", "rounded-lg", "text-base", "text-ink-400", "text-ink-900", "text-xl"},
+		},
+		{
+			name:      "svelte expressions and directives",
+			path:      "testdata/svelte/Toggle.svelte",
+			extractor: SvelteExtractor,
+			classes:   []string{"bg-blue-500", "bg-ink-200", "inline-flex", "items-center", "opacity-50", "ring-2", "rounded-full", "translate-x-0", "translate-x-4"},
+		},
+		{
+			name:      "astro class:list",
+			path:      "testdata/astro/Hero.astro",
+			extractor: AstroExtractor,
+			classes:   []string{"bg-ink-950", "font-bold", "py-16", "text-4xl", "text-base", "text-center", "text-lg", "text-white"},
+		},
+		{
+			name:      "go class helpers and tags",
+			path:      "testdata/go/card.go",
+			extractor: GoExtractor,
+			classes:   []string{"bg-ink-950", "flex", "font-bold", "gap-4", "p-4", "rounded-full", "rounded-lg", "shadow-md", "text-lg", "text-xl"},
+		},
+		{
+			name:      "templ attributes and KV",
+			path:      "testdata/templ/button.templ",
+			extractor: TemplExtractor,
+			classes:   []string{"bg-blue-500", "bg-ink-200", "inline-flex", "items-center", "opacity-50", "px-4", "py-2", "rounded-md", "text-white"},
+		},
+		{
+			name:      "jsx nested calls, objects, and template literals",
+			path:      "testdata/jsx/Panel.tsx",
+			extractor: JSXExtractor,
+			classes:   []string{"bg-white", "border", "border-ink-200", "flex", "flex-col", "font-bold", "gap-2", "gap-4", "hidden", "italic", "mt-2", "p-4", "rounded", "shadow-md", "text-sm"},
+		},
+		{
+			name:      "markdown attribute lists and inline html",
+			path:      "testdata/markdown/guide.md",
+			extractor: MarkdownExtractor,
+			classes:   []string{"border", "font-bold", "mt-8", "p-4", "rounded-lg", "text-4xl"},
+		},
+		{
+			name:      "mdx jsx expressions",
+			path:      "testdata/markdown/page.mdx",
+			extractor: MarkdownExtractor,
+			classes:   []string{"bg-ink-950", "gap-4", "grid", "md:grid-cols-3", "p-6"},
+		},
+		{
+			name:      "json selectors",
+			path:      "testdata/data/page.json",
+			extractor: DataExtractor,
+			classes:   []string{"bg-ink-950", "gap-4", "grid", "md:grid-cols-3", "p-4", "py-16", "rounded-lg", "text-white"},
+		},
+		{
+			name:      "yaml selectors across flow mappings and documents",
+			path:      "testdata/data/page.yaml",
+			extractor: DataExtractor,
+			classes:   []string{"bg-white", "border-t", "gap-6", "grid", "p-6", "px-2", "py-12", "rounded-full", "rounded-md", "shadow-md", "text-center"},
+		},
+		{
+			name:      "safelist html comment",
+			path:      "testdata/safelist/status.html",
+			extractor: MarkupExtractor,
+			classes:   []string{"badge", "bg-amber-500", "bg-green-500", "bg-red-500", "text-white"},
+		},
+		{
+			name:      "safelist line and block comments",
+			path:      "testdata/safelist/status.js",
+			extractor: JSXExtractor,
+			classes:   []string{"ring-2", "ring-green-500", "ring-red-500", "text-green-600", "text-green-700", "text-red-600", "text-red-700"},
+		},
+		{
+			name:      "safelist hash comment with range",
+			path:      "testdata/safelist/settings.yaml",
+			extractor: DataExtractor,
+			classes:   []string{"flex", "p-2", "p-3", "p-4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FromPaths([]string{tt.path}, nil, Options{})
+			if err != nil {
+				t.Fatalf("FromPaths: %v", err)
+			}
+			if result.Files != 1 {
+				t.Fatalf("Files = %d, want 1", result.Files)
+			}
+			if got := result.Extractors[filepath.Clean(tt.path)]; got != tt.extractor {
+				t.Errorf("extractor = %q, want %q", got, tt.extractor)
+			}
+			if !slices.Equal(result.Classes, tt.classes) {
+				t.Errorf("classes = %q, want %q", result.Classes, tt.classes)
+			}
+		})
+	}
+}
+
+func TestFromPathsSkipsUnparseableData(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"tsconfig.json": "{\n  // comment\n  \"compilerOptions\": {}\n}\n",
+		"anchors.yaml":  "base: &base\n  class: p-4\ncard:\n  <<: *base\n",
+		"page.yaml":     "class: \"flex gap-2\"\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := FromPaths([]string{filepath.Join(dir, "*.{json,yaml}")}, nil, Options{})
+	if err != nil {
+		t.Fatalf("FromPaths: %v", err)
+	}
+	if want := []string{"flex", "gap-2"}; !slices.Equal(result.Classes, want) {
+		t.Errorf("classes = %q, want %q", result.Classes, want)
+	}
+	if len(result.Warnings) != 2 {
+		t.Fatalf("warnings = %q, want 2", result.Warnings)
+	}
+	for _, warning := range result.Warnings {
+		if !strings.HasPrefix(warning, "skipped ") {
+			t.Errorf("warning %q does not report a skipped file", warning)
+		}
+	}
+}
+
+func TestFromPathsRejectsUnknownExtractor(t *testing.T) {
+	_, err := FromPaths(nil, nil, Options{Extractors: map[string]string{".foo": "nope"}})
+	if err == nil || !strings.Contains(err.Error(), `build.extractors[.foo]: unknown extractor "nope"`) {
+		t.Fatalf("FromPaths error = %v, want an unknown extractor error", err)
+	}
+}
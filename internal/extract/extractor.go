@@ -0,0 +1,136 @@
+package extract
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	MarkupExtractor = "html"
+	WordsExtractor  = "words"
+)
+
+var wordPattern = regexp.MustCompile(`[a-zA-Z0-9_:/%-]+`)
+
+type Extractor interface {
+	Extract(content string) ([]string, error)
+}
+
+type ExtractorFunc func(content string) []string
+
+func (f ExtractorFunc) Extract(content string) ([]string, error) {
+	return f(content), nil
+}
+
+type Options struct {
+	Extractors     map[string]string
+	ClassFunctions []string
+	DataSelectors  []string
+	Workers        int
+}
+
+var defaultExtensions = map[string]string{
+	".js":       JSXExtractor,
+	".jsx":      JSXExtractor,
+	".mjs":      JSXExtractor,
+	".ts":       JSXExtractor,
+	".tsx":      JSXExtractor,
+	".vue":      VueExtractor,
+	".svelte":   SvelteExtractor,
+	".astro":    AstroExtractor,
+	".go":       GoExtractor,
+	".templ":    TemplExtractor,
+	".md":       MarkdownExtractor,
+	".markdown": MarkdownExtractor,
+	".mdx":      MarkdownExtractor,
+	".json":     DataExtractor,
+	".yaml":     DataExtractor,
+	".yml":      DataExtractor,
+}
+
+func builtinExtractors(options Options) map[string]Extractor {
+	return map[string]Extractor{
+		MarkupExtractor:   ExtractorFunc(extractClasses),
+		WordsExtractor:    ExtractorFunc(extractWords),
+		JSXExtractor:      newJSXExtractor(options.ClassFunctions),
+		VueExtractor:      ExtractorFunc(extractClasses),
+		SvelteExtractor:   ExtractorFunc(extractComponentClasses),
+		AstroExtractor:    ExtractorFunc(extractComponentClasses),
+		GoExtractor:       newGoExtractor(options.ClassFunctions),
+		TemplExtractor:    templExtractor{newGoExtractor(options.ClassFunctions)},
+		MarkdownExtractor: markdownExtractor{jsx: newJSXExtractor(options.ClassFunctions)},
+		DataExtractor:     newDataExtractor(options.DataSelectors),
+	}
+}
+
+func ExtractorNames() []string {
+	extractors := builtinExtractors(Options{})
+	names := make([]string, 0, len(extractors))
+	for name := range extractors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type extractorSet struct {
+	byName      map[string]Extractor
+	byExtension map[string]string
+}
+
+func newExtractorSet(options Options) (extractorSet, error) {
+	if _, err := parseSelectors(options.DataSelectors); err != nil {
+		return extractorSet{}, err
+	}
+	set := extractorSet{
+		byName:      builtinExtractors(options),
+		byExtension: map[string]string{},
+	}
+	for ext, name := range defaultExtensions {
+		set.byExtension[ext] = name
+	}
+	exts := make([]string, 0, len(options.Extractors))
+	for ext := range options.Extractors {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	for _, ext := range exts {
+		name := options.Extractors[ext]
+		if _, ok := set.byName[name]; !ok {
+			return extractorSet{}, fmt.Errorf("build.extractors[%s]: unknown extractor %q (available: %s)", ext, name, strings.Join(ExtractorNames(), ", "))
+		}
+		set.byExtension[normalizeExtension(ext)] = name
+	}
+	return set, nil
+}
+
+func (s extractorSet) forFile(path string) (string, Extractor) {
+	name, ok := s.byExtension[normalizeExtension(filepath.Ext(path))]
+	if !ok {
+		name = MarkupExtractor
+	}
+	return name, s.byName[name]
+}
+
+func normalizeExtension(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+func extractWords(content string) []string {
+	var classes []string
+	for _, word := range wordPattern.FindAllString(content, -1) {
+		word = strings.Trim(word, ":/")
+		if word == "" || strings.Trim(word, "0123456789-_%") == "" {
+			continue
+		}
+		classes = append(classes, word)
+	}
+	return classes
+}
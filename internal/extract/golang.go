@@ -0,0 +1,154 @@
+package extract
+
+import (
+	"go/scanner"
+	"go/token"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	GoExtractor    = "go"
+	TemplExtractor = "templ"
+)
+
+var classIdentPattern = regexp.MustCompile(`^(?:[cC]lass(?:es|Name)?|\w+(?:Class|Classes|ClassName))$`)
+
+type goExtractor struct {
+	functions map[string]struct{}
+}
+
+func newGoExtractor(functions []string) goExtractor {
+	if len(functions) == 0 {
+		functions = DefaultClassFunctions
+	}
+	set := make(map[string]struct{}, len(functions))
+	for _, name := range functions {
+		if name = strings.TrimSpace(name); name != "" {
+			set[name] = struct{}{}
+		}
+	}
+	return goExtractor{functions: set}
+}
+
+func (e goExtractor) Extract(content string) ([]string, error) {
+	return e.scan(content, nil, false), nil
+}
+
+func (e goExtractor) isClassFunction(callee []string) bool {
+	if len(callee) == 0 {
+		return false
+	}
+	_, ok := e.functions[strings.Join(callee, ".")]
+	return ok
+}
+
+func (e goExtractor) scan(content string, skip [][2]int, all bool) []string {
+	src := []byte(content)
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, src, func(token.Position, string) {}, 0)
+
+	var classes, pending []string
+	var callee []string
+	calls := []bool{all}
+	prev, prevPrev := token.ILLEGAL, token.ILLEGAL
+	prevLit := ""
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok != token.EQL && tok != token.NEQ {
+			classes = append(classes, pending...)
+		}
+		pending = nil
+
+		switch tok {
+		case token.IDENT:
+			if prev == token.PERIOD && len(callee) > 0 {
+				callee = append(callee, lit)
+			} else {
+				callee = []string{lit}
+			}
+		case token.PERIOD:
+		case token.LPAREN:
+			// Only direct arguments of a class function count; a nested call
+			// such as fmt.Sprintf builds its own value. Parentheses without a
+			// callee just group, so they keep the enclosing context.
+			direct := calls[len(calls)-1]
+			if len(callee) > 0 {
+				direct = e.isClassFunction(callee)
+			}
+			calls = append(calls, direct)
+			callee = nil
+		case token.RPAREN:
+			if len(calls) > 1 {
+				calls = calls[:len(calls)-1]
+			}
+			callee = nil
+		case token.STRING:
+			callee = nil
+			offset := file.Offset(pos)
+			if inSpans(skip, offset) || prev == token.EQL || prev == token.NEQ {
+				break
+			}
+			value, err := strconv.Unquote(lit)
+			if err != nil {
+				break
+			}
+			switch {
+			case calls[len(calls)-1]:
+				pending = splitClasses(value)
+			case prevPrev == token.IDENT && classIdentPattern.MatchString(prevLit) && (prev == token.DEFINE || prev == token.ASSIGN || prev == token.ADD_ASSIGN || prev == token.COLON):
+				pending = splitClasses(value)
+			case strings.HasPrefix(lit, "`"):
+				if tag, ok := reflect.StructTag(value).Lookup("class"); ok {
+					pending = splitClasses(tag)
+				}
+			}
+		default:
+			callee = nil
+		}
+
+		if tok == token.IDENT {
+			prevLit = lit
+		} else if prev != token.IDENT {
+			prevLit = ""
+		}
+		prevPrev, prev = prev, tok
+	}
+	return append(classes, pending...)
+}
+
+func inSpans(spans [][2]int, offset int) bool {
+	for _, span := range spans {
+		if offset >= span[0] && offset < span[1] {
+			return true
+		}
+	}
+	return false
+}
+
+type templExtractor struct {
+	goExtractor
+}
+
+func (e templExtractor) Extract(content string) ([]string, error) {
+	classes := extractAttrClasses(classAttrPattern, content)
+
+	var spans [][2]int
+	for _, match := range classAttrPattern.FindAllStringIndex(content, -1) {
+		spans = append(spans, [2]int{match[0], match[1]})
+	}
+	for _, match := range componentExprPattern.FindAllStringIndex(content, -1) {
+		open := match[1] - 1
+		end := matchingClose(content, open)
+		spans = append(spans, [2]int{open, end})
+		classes = append(classes, e.scan(content[open+1:max(open+1, end-1)], nil, true)...)
+	}
+	return append(classes, e.scan(content, spans, false)...), nil
+}
@@ -0,0 +1,134 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ignoreFileNames = []string{".gitignore", ".lcssignore"}
+
+type ignoreRule struct {
+	dir      string
+	pattern  []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+type ignoreMatcher struct {
+	cwd   string
+	rules map[string][]ignoreRule
+	roots map[string]bool
+}
+
+func newIgnoreMatcher() *ignoreMatcher {
+	cwd, _ := os.Getwd()
+	return &ignoreMatcher{cwd: cwd, rules: map[string][]ignoreRule{}, roots: map[string]bool{}}
+}
+
+func (m *ignoreMatcher) ignored(path string, isDir bool) bool {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(m.cwd, path)
+	}
+	path = filepath.Clean(path)
+	ignored := false
+	for _, dir := range m.ancestorDirs(path) {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		segments := strings.Split(filepath.ToSlash(rel), "/")
+		for _, rule := range m.rulesFor(dir) {
+			if rule.dirOnly && !isDir {
+				continue
+			}
+			if rule.matches(segments) {
+				ignored = !rule.negate
+			}
+		}
+	}
+	return ignored
+}
+
+func (m *ignoreMatcher) rulesFor(dir string) []ignoreRule {
+	if rules, ok := m.rules[dir]; ok {
+		return rules
+	}
+	var rules []ignoreRule
+	for _, name := range ignoreFileNames {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		rules = append(rules, parseIgnoreRules(dir, string(data))...)
+	}
+	m.rules[dir] = rules
+	return rules
+}
+
+func parseIgnoreRules(dir, content string) []ignoreRule {
+	var rules []ignoreRule
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if !strings.HasSuffix(line, `\ `) {
+			line = strings.TrimRight(line, " \t")
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{dir: dir}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		rule.pattern = strings.Split(line, "/")
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func (r ignoreRule) matches(segments []string) bool {
+	if r.anchored {
+		return matchSegments(r.pattern, segments)
+	}
+	return matchSegments(r.pattern, segments[len(segments)-1:])
+}
+
+// ancestorDirs lists the directories above an absolute path, outermost first,
+// up to the nearest one containing .git or the filesystem root. The working
+// directory plays no part, so running from a subdirectory sees the same rules.
+func (m *ignoreMatcher) ancestorDirs(path string) []string {
+	var dirs []string
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if m.isProjectRoot(dir) || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+	for i, j := 0, len(dirs)-1; i < j; i, j = i+1, j-1 {
+		dirs[i], dirs[j] = dirs[j], dirs[i]
+	}
+	return dirs
+}
+
+func (m *ignoreMatcher) isProjectRoot(dir string) bool {
+	if root, ok := m.roots[dir]; ok {
+		return root
+	}
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	m.roots[dir] = err == nil
+	return m.roots[dir]
+}
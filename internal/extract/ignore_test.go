@@ -0,0 +1,218 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParseIgnoreRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []ignoreRule
+	}{
+		{
+			name:    "comments and blank lines",
+			content: "# build output\n\n   \n",
+		},
+		{
+			name:    "basename",
+			content: "*.log\n",
+			want:    []ignoreRule{{dir: "/p", pattern: []string{"*.log"}}},
+		},
+		{
+			name:    "directory only",
+			content: "node_modules/\n",
+			want:    []ignoreRule{{dir: "/p", pattern: []string{"node_modules"}, dirOnly: true}},
+		},
+		{
+			name:    "anchored",
+			content: "/dist\ndocs/*.md\n",
+			want: []ignoreRule{
+				{dir: "/p", pattern: []string{"dist"}, anchored: true},
+				{dir: "/p", pattern: []string{"docs", "*.md"}, anchored: true},
+			},
+		},
+		{
+			name:    "negated re-include",
+			content: "!keep.html\n!/public/\n",
+			want: []ignoreRule{
+				{dir: "/p", pattern: []string{"keep.html"}, negate: true},
+				{dir: "/p", pattern: []string{"public"}, negate: true, dirOnly: true, anchored: true},
+			},
+		},
+		{
+			name:    "escaped hash and bang",
+			content: "\\#notes.md\n\\!important.md\r\n",
+			want: []ignoreRule{
+				{dir: "/p", pattern: []string{"#notes.md"}},
+				{dir: "/p", pattern: []string{"!important.md"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseIgnoreRules("/p", tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseIgnoreRules = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreMatcher(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		".git/HEAD":            "",
+		".gitignore":           "*.log\n!keep.log\nbuild/\n/root-only.html\n",
+		"app/.lcssignore":      "drafts/\n",
+		"app/drafts/a.html":    "",
+		"app/keep.log":         "",
+		"app/root-only.html":   "",
+		"app/build":            "",
+		"app/debug.log":        "",
+		"app/build-dir/x.html": "",
+	})
+	matcher := newIgnoreMatcher()
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{path: "app/debug.log", want: true},
+		{path: "app/keep.log", want: false},
+		{path: "build", isDir: true, want: true},
+		{path: "app/build", isDir: false, want: false},
+		{path: "root-only.html", want: true},
+		{path: "app/root-only.html", want: false},
+		{path: "app/drafts", isDir: true, want: true},
+		{path: "drafts", isDir: true, want: false},
+	}
+	for _, tt := range tests {
+		if got := matcher.ignored(filepath.Join(root, tt.path), tt.isDir); got != tt.want {
+			t.Errorf("ignored(%s, dir=%v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestExpandPatterns(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		".git/HEAD":             "",
+		".gitignore":            "generated/\n",
+		"src/index.html":        "",
+		"src/App.vue":           "",
+		"src/app.js":            "",
+		"src/legacy/old.html":   "",
+		"src/parts/card.html":   "",
+		"src/generated/x.html":  "",
+		"src/generated/y.vue":   "",
+		"generated/top.html":    "",
+		"generated/nested.html": "",
+	})
+	t.Chdir(root)
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{
+			name:     "brace globs",
+			patterns: []string{"src/**/*.{html,vue}"},
+			want:     []string{"src/App.vue", "src/index.html", "src/legacy/old.html", "src/parts/card.html"},
+		},
+		{
+			name:     "negations",
+			patterns: []string{"src/**/*.html", "!src/legacy/**", "!src/parts/card.html"},
+			want:     []string{"src/index.html"},
+		},
+		{
+			name:     "negated braces",
+			patterns: []string{"src/*.*", "!src/*.{js,vue}"},
+			want:     []string{"src/index.html"},
+		},
+		{
+			name:     "literal root overrides ignore files",
+			patterns: []string{"generated/*.html"},
+			want:     []string{"generated/nested.html", "generated/top.html"},
+		},
+		{
+			name:     "wildcard directory is checked",
+			patterns: []string{"*/*.html"},
+			want:     []string{"src/index.html"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandPatterns(tt.patterns)
+			if err != nil {
+				t.Fatalf("expandPatterns: %v", err)
+			}
+			slices.Sort(got)
+			want := make([]string, len(tt.want))
+			for i, path := range tt.want {
+				want[i] = filepath.FromSlash(path)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("expandPatterns = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestContentFilterFromSubdirectory(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		".git/HEAD":               "",
+		".gitignore":              "generated/\n*.tmp.html\n",
+		"app/index.html":          "",
+		"app/scratch.tmp.html":    "",
+		"app/generated/x.html":    "",
+		"app/parts/generated.txt": "",
+	})
+	t.Chdir(filepath.Join(root, "app"))
+	filter := contentFilter{ignore: newIgnoreMatcher()}
+
+	if !filter.skipEntry("generated", true) {
+		t.Errorf("skipEntry(generated) = false; the .gitignore above the working directory was not read")
+	}
+	if !filter.skipEntry("scratch.tmp.html", false) {
+		t.Errorf("skipEntry(scratch.tmp.html) = false, want true")
+	}
+	if filter.skipEntry("parts/generated.txt", false) {
+		t.Errorf("skipEntry(parts/generated.txt) = true; generated/ only matches directories")
+	}
+	if !filter.skipMatch(".", filepath.Join("generated", "x.html")) {
+		t.Errorf("skipMatch(., generated/x.html) = false, want true")
+	}
+	if filter.skipMatch("generated", filepath.Join("generated", "x.html")) {
+		t.Errorf("skipMatch(generated, generated/x.html) = true; a literal root overrides ignore files")
+	}
+
+	got, err := expandPatterns([]string{"./**/*.html"})
+	if err != nil {
+		t.Fatalf("expandPatterns: %v", err)
+	}
+	if want := []string{"index.html"}; !slices.Equal(got, want) {
+		t.Errorf("expandPatterns = %q, want %q", got, want)
+	}
+}
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
@@ -0,0 +1,234 @@
+package extract
+
+import (
+	"regexp"
+	"strings"
+)
+
+const JSXExtractor = "jsx"
+
+var (
+	jsxAttrPattern       = regexp.MustCompile(`\b(?:class|className)\s*=\s*\{`)
+	classListCallPattern = regexp.MustCompile(`\bclassList\s*\.\s*(?:add|toggle)\s*\(`)
+)
+
+var DefaultClassFunctions = []string{"clsx", "cn", "cva", "cx", "classnames", "templ.KV", "templ.Classes"}
+
+type jsxExtractor struct {
+	calls *regexp.Regexp
+}
+
+func newJSXExtractor(functions []string) jsxExtractor {
+	if len(functions) == 0 {
+		functions = DefaultClassFunctions
+	}
+	names := make([]string, 0, len(functions))
+	for _, name := range functions {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, regexp.QuoteMeta(name))
+		}
+	}
+	var calls *regexp.Regexp
+	if len(names) > 0 {
+		calls = regexp.MustCompile(`(?:^|[^\w$])(?:` + strings.Join(names, "|") + `)\s*\(`)
+	}
+	return jsxExtractor{calls: calls}
+}
+
+func (e jsxExtractor) Extract(content string) ([]string, error) {
+	var classes []string
+	classes = append(classes, extractAttrClasses(classAttrPattern, content)...)
+	classes = append(classes, extractAttrClasses(classNameAttrPattern, content)...)
+
+	var spans [][2]int
+	inSpan := func(pos int) bool {
+		for _, span := range spans {
+			if pos >= span[0] && pos < span[1] {
+				return true
+			}
+		}
+		return false
+	}
+	collect := func(pattern *regexp.Regexp) {
+		if pattern == nil {
+			return
+		}
+		for _, match := range pattern.FindAllStringIndex(content, -1) {
+			open := match[1] - 1
+			if inSpan(open) {
+				continue
+			}
+			end := matchingClose(content, open)
+			spans = append(spans, [2]int{open, end})
+			classes = append(classes, expressionClasses(content[open+1:max(open+1, end-1)])...)
+		}
+	}
+	collect(jsxAttrPattern)
+	collect(e.calls)
+	collect(classListCallPattern)
+	return classes, nil
+}
+
+func matchingClose(content string, open int) int {
+	closer := map[byte]byte{'{': '}', '(': ')', '[': ']'}
+	var stack []byte
+	for i := open; i < len(content); {
+		c := content[i]
+		switch c {
+		case '"', '\'', '`':
+			i = skipLiteral(content, i)
+			continue
+		case '{', '(', '[':
+			stack = append(stack, closer[c])
+		case '}', ')', ']':
+			if len(stack) > 0 && stack[len(stack)-1] == c {
+				stack = stack[:len(stack)-1]
+				if len(stack) == 0 {
+					return i + 1
+				}
+			}
+		}
+		i++
+	}
+	return len(content)
+}
+
+func skipLiteral(content string, start int) int {
+	quote := content[start]
+	for i := start + 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		case '$':
+			if quote == '`' && i+1 < len(content) && content[i+1] == '{' {
+				i = matchingClose(content, i+1) - 1
+			}
+		case '\n':
+			if quote != '`' {
+				return i
+			}
+		}
+	}
+	return len(content)
+}
+
+func expressionClasses(expr string) []string {
+	var classes []string
+	last := byte('{')
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == '"' || c == '\'':
+			end := skipLiteral(expr, i)
+			if !isComparison(last, expr[end:]) {
+				classes = append(classes, splitClasses(expr[i+1:max(i+1, end-1)])...)
+			}
+			i, last = end, c
+			continue
+		case c == '`':
+			end := skipLiteral(expr, i)
+			classes = append(classes, templateClasses(expr[i+1:max(i+1, end-1)])...)
+			i, last = end, c
+			continue
+		case c == '/' && i+1 < len(expr) && expr[i+1] == '/':
+			if end := strings.IndexByte(expr[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(expr)
+			}
+			continue
+		case isIdentStart(c):
+			end := i
+			for end < len(expr) && isIdentPart(expr[end]) {
+				end++
+			}
+			if (last == '{' || last == ',') && objectKeyFollows(expr[end:]) {
+				classes = append(classes, expr[i:end])
+			}
+			i, last = end, 'a'
+			continue
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			last = c
+		}
+		i++
+	}
+	return classes
+}
+
+func isComparison(last byte, rest string) bool {
+	if last == '=' {
+		return true
+	}
+	rest = strings.TrimLeft(rest, " \t\r\n")
+	return strings.HasPrefix(rest, "==") || strings.HasPrefix(rest, "!=")
+}
+
+func objectKeyFollows(rest string) bool {
+	rest = strings.TrimLeft(rest, " \t\r\n")
+	if !strings.HasPrefix(rest, ":") {
+		return false
+	}
+	value := strings.TrimLeft(rest[1:], " \t\r\n")
+	return value != "" && !strings.ContainsRune("{\"'`", rune(value[0]))
+}
+
+func templateClasses(literal string) []string {
+	return interpolatedClasses(literal, "${")
+}
+
+func interpolatedClasses(literal, opener string) []string {
+	var classes []string
+	segmentStart := 0
+	afterInterpolation := false
+	flush := func(segment string, beforeInterpolation bool) {
+		fields := strings.Fields(segment)
+		if len(fields) == 0 {
+			return
+		}
+		if afterInterpolation && !startsWithSpace(segment) {
+			fields = fields[1:]
+		}
+		if beforeInterpolation && len(fields) > 0 && !endsWithSpace(segment) {
+			fields = fields[:len(fields)-1]
+		}
+		classes = append(classes, fields...)
+	}
+	for i := 0; i < len(literal); i++ {
+		switch literal[i] {
+		case '\\':
+			i++
+		default:
+			if !strings.HasPrefix(literal[i:], opener) {
+				continue
+			}
+			flush(literal[segmentStart:i], true)
+			open := i + len(opener) - 1
+			end := matchingClose(literal, open)
+			classes = append(classes, expressionClasses(literal[open+1:max(open+1, end-1)])...)
+			segmentStart, afterInterpolation = end, true
+			i = end - 1
+		}
+	}
+	flush(literal[min(segmentStart, len(literal)):], false)
+	return classes
+}
+
+func startsWithSpace(value string) bool {
+	return value != "" && strings.ContainsRune(" \t\r\n", rune(value[0]))
+}
+
+func endsWithSpace(value string) bool {
+	return value != "" && strings.ContainsRune(" \t\r\n", rune(value[len(value)-1]))
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
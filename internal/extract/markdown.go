@@ -0,0 +1,91 @@
+package extract
+
+import (
+	"regexp"
+	"strings"
+)
+
+const MarkdownExtractor = "markdown"
+
+var (
+	fencePattern    = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	attrListPattern = regexp.MustCompile(`\{:?[ \t]*([^{}\n]*)\}`)
+)
+
+type markdownExtractor struct {
+	jsx jsxExtractor
+}
+
+func (e markdownExtractor) Extract(content string) ([]string, error) {
+	prose := stripMarkdownCode(content)
+	classes, err := e.jsx.Extract(prose)
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range attrListPattern.FindAllStringSubmatch(prose, -1) {
+		for _, field := range strings.Fields(match[1]) {
+			if strings.HasPrefix(field, ".") {
+				classes = append(classes, field[1:])
+			}
+		}
+	}
+	return classes, nil
+}
+
+func stripMarkdownCode(content string) string {
+	lines := strings.Split(content, "\n")
+	fence := ""
+	depth := 0
+	for i, line := range lines {
+		if fence != "" {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			}
+			lines[i] = ""
+			continue
+		}
+		if match := fencePattern.FindStringSubmatch(line); match != nil {
+			fence = match[1]
+			depth = 0
+			lines[i] = ""
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			depth = 0
+			continue
+		}
+		lines[i], depth = stripInlineCode(line, depth)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func stripInlineCode(line string, depth int) (string, int) {
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '{':
+			depth++
+		case c == '}' && depth > 0:
+			depth--
+		case c == '`' && depth == 0:
+			run := 1
+			for i+run < len(line) && line[i+run] == '`' {
+				run++
+			}
+			ticks := line[i : i+run]
+			end := strings.Index(line[i+run:], ticks)
+			if end < 0 {
+				b.WriteString(ticks)
+				i += run - 1
+				continue
+			}
+			b.WriteString(strings.Repeat(" ", run+end+run))
+			i += run + end + run - 1
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), depth
+}
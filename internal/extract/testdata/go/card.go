@@ -0,0 +1,26 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Card struct {
+	Title string `json:"title" class:"rounded-lg shadow-md"`
+	Body  string
+}
+
+func cardClasses(featured bool, size string) string {
+	class := "p-4 flex"
+	if featured {
+		class += " bg-ink-950"
+	}
+	headerClass := `text-xl font-bold`
+	_ = headerClass
+	badge := cn(fmt.Sprintf("%s-500", size), "rounded-full")
+	return strings.Join([]string{class, badge, cn("gap-4", size == "lg" && "text-lg")}, " ")
+}
+
+func cn(parts ...any) string { return "" }
+
+var ignored = "not a class list"
@@ -0,0 +1,21 @@
+import { cn } from "./cn";
+
+type PanelProps = { open: boolean; tone: "info" | "warn"; count: number };
+
+export function Panel({ open, tone, count }: PanelProps) {
+  const label = format("(%d)", count);
+  return (
+    <section
+      className={cn(
+        "flex flex-col",
+        wrap(open ? ("gap-4") : "gap-2"),
+        { "shadow-md": open && count > 0, hidden: !open, rounded: isRounded(tone) },
+        `border ${tone === "warn" ? `border-${tone}-500 bg-white` : "border-ink-200"} p-4`,
+        label.endsWith(")") && "italic",
+        "mt-2"
+      )}
+    >
+      <span className={`text-sm ${count > 9 ? "font-bold" : ""}`}>{label}</span>
+    </section>
+  );
+}
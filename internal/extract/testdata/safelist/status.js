@@ -0,0 +1,5 @@
+// lcss: text-{red,green}-{600,700}
+/* lcss:
+ * ring-2 ring-{red,green}-500
+ */
+export const tone = (status) => `text-${status}-600`;
@@ -0,0 +1,388 @@
+package extract
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var yamlAnchorPattern = regexp.MustCompile(`^[&*][^\s,\[\]{}]+(?:\s|$)`)
+
+type yamlLine struct {
+	indent int
+	text   string
+	number int
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// decodeYAML decodes the block-style subset of YAML that content files use:
+// mappings, sequences, plain and quoted scalars, block scalars (| and >),
+// single-line flow collections, and multiple documents separated by ---.
+// Anchors, aliases, merge keys, tags on collections, and flow collections that
+// span lines are reported as errors rather than silently dropped.
+func decodeYAML(content string) ([]any, error) {
+	var documents [][]yamlLine
+	var lines []yamlLine
+	endDocument := func() {
+		if len(lines) > 0 {
+			documents = append(documents, lines)
+		}
+		lines = nil
+	}
+	for i, raw := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		text := stripYAMLComment(raw)
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" {
+			continue
+		}
+		if trimmed == "---" || trimmed == "..." {
+			endDocument()
+			continue
+		}
+		indent := len(text) - len(strings.TrimLeft(text, " "))
+		if strings.HasPrefix(text[indent:], "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		lines = append(lines, yamlLine{indent: indent, text: strings.TrimRight(text[indent:], " \t"), number: i + 1})
+	}
+	endDocument()
+
+	values := make([]any, 0, len(documents))
+	for _, lines := range documents {
+		p := &yamlParser{lines: lines}
+		value, err := p.parseBlock(lines[0].indent)
+		if err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.lines) {
+			return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].number)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (p *yamlParser) parseBlock(indent int) (any, error) {
+	if isYAMLSequenceItem(p.lines[p.pos].text) {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+func (p *yamlParser) parseSequence(indent int) (any, error) {
+	items := []any{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent != indent || !isYAMLSequenceItem(line.text) {
+			break
+		}
+		text := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		if text == "" {
+			p.pos++
+			value, err := p.parseNested(indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+			continue
+		}
+		if _, _, ok := splitYAMLKey(text); ok {
+			p.lines[p.pos] = yamlLine{indent: indent + len(line.text) - len(text), text: text, number: line.number}
+			value, err := p.parseMapping(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+			continue
+		}
+		p.pos++
+		value, err := p.parseValue(text, indent)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+	return items, nil
+}
+
+func (p *yamlParser) parseMapping(indent int) (any, error) {
+	values := map[string]any{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent != indent || isYAMLSequenceItem(line.text) {
+			break
+		}
+		key, rest, ok := splitYAMLKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key: value", line.number)
+		}
+		if key == "<<" {
+			return nil, fmt.Errorf("line %d: merge keys are not supported", line.number)
+		}
+		p.pos++
+		if rest == "" {
+			if p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLSequenceItem(p.lines[p.pos].text) {
+				value, err := p.parseSequence(indent)
+				if err != nil {
+					return nil, err
+				}
+				values[key] = value
+				continue
+			}
+			value, err := p.parseNested(indent)
+			if err != nil {
+				return nil, err
+			}
+			values[key] = value
+			continue
+		}
+		value, err := p.parseValue(rest, indent)
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func (p *yamlParser) parseNested(indent int) (any, error) {
+	if p.pos >= len(p.lines) || p.lines[p.pos].indent <= indent {
+		return nil, nil
+	}
+	return p.parseBlock(p.lines[p.pos].indent)
+}
+
+func (p *yamlParser) parseValue(text string, indent int) (any, error) {
+	if strings.HasPrefix(text, "|") || strings.HasPrefix(text, ">") {
+		return p.continuation(indent, ""), nil
+	}
+	if strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+		value, err := parseYAMLFlow(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", p.lines[p.pos-1].number, err)
+		}
+		return value, nil
+	}
+	value, err := parseYAMLScalar(text)
+	if err != nil {
+		return nil, err
+	}
+	if text[0] != '"' && text[0] != '\'' {
+		return p.continuation(indent, value), nil
+	}
+	return value, nil
+}
+
+func (p *yamlParser) continuation(indent int, first string) string {
+	parts := []string{}
+	if first != "" {
+		parts = append(parts, first)
+	}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		parts = append(parts, p.lines[p.pos].text)
+		p.pos++
+	}
+	return strings.Join(parts, " ")
+}
+
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func splitYAMLKey(text string) (string, string, bool) {
+	quote := byte(0)
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 {
+				quote = c
+			}
+		case c == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			key, err := parseYAMLScalar(strings.TrimSpace(text[:i]))
+			if err != nil || key == "" {
+				return "", "", false
+			}
+			return key, strings.TrimSpace(text[i+1:]), true
+		case c == '[' || c == '{':
+			if i == 0 {
+				return "", "", false
+			}
+		}
+	}
+	return "", "", false
+}
+
+func parseYAMLScalar(text string) (string, error) {
+	if len(text) >= 2 && text[0] == '"' && text[len(text)-1] == '"' {
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return "", fmt.Errorf("invalid double-quoted string %s", text)
+		}
+		return value, nil
+	}
+	if len(text) >= 2 && text[0] == '\'' && text[len(text)-1] == '\'' {
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+	if yamlAnchorPattern.MatchString(text) {
+		return "", fmt.Errorf("anchors and aliases are not supported (%s)", text)
+	}
+	return text, nil
+}
+
+type yamlFlowParser struct {
+	text string
+	pos  int
+}
+
+// parseYAMLFlow parses a [sequence] or {mapping} written on a single line.
+func parseYAMLFlow(text string) (any, error) {
+	p := &yamlFlowParser{text: text}
+	value, err := p.parseCollection()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.text) {
+		return nil, fmt.Errorf("unexpected %q after flow collection", p.text[p.pos:])
+	}
+	return value, nil
+}
+
+func (p *yamlFlowParser) parseCollection() (any, error) {
+	mapping := p.text[p.pos] == '{'
+	closer := byte(']')
+	if mapping {
+		closer = '}'
+	}
+	p.pos++
+	items := []any{}
+	values := map[string]any{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.text) {
+			return nil, fmt.Errorf("unterminated flow collection %s (flow collections must fit on one line)", p.text)
+		}
+		if p.text[p.pos] == closer {
+			p.pos++
+			break
+		}
+		node, err := p.parseNode(mapping)
+		if err != nil {
+			return nil, err
+		}
+		if mapping {
+			key, ok := node.(string)
+			if !ok {
+				return nil, fmt.Errorf("flow mapping keys must be scalars in %s", p.text)
+			}
+			var value any
+			p.skipSpace()
+			if p.pos < len(p.text) && p.text[p.pos] == ':' {
+				p.pos++
+				p.skipSpace()
+				if p.pos < len(p.text) && p.text[p.pos] != ',' && p.text[p.pos] != '}' {
+					if value, err = p.parseNode(true); err != nil {
+						return nil, err
+					}
+				}
+			}
+			values[key] = value
+		} else {
+			items = append(items, node)
+		}
+		p.skipSpace()
+		if p.pos < len(p.text) && p.text[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		if p.pos < len(p.text) && p.text[p.pos] == closer {
+			p.pos++
+			break
+		}
+		return nil, fmt.Errorf("expected , or %c in %s", closer, p.text)
+	}
+	if mapping {
+		return values, nil
+	}
+	return items, nil
+}
+
+func (p *yamlFlowParser) parseNode(inMapping bool) (any, error) {
+	start := p.pos
+	switch p.text[p.pos] {
+	case '[', '{':
+		return p.parseCollection()
+	case '"', '\'':
+		quote := p.text[p.pos]
+		for p.pos++; p.pos < len(p.text); p.pos++ {
+			c := p.text[p.pos]
+			if quote == '"' && c == '\\' {
+				p.pos++
+				continue
+			}
+			if c != quote {
+				continue
+			}
+			if quote == '\'' && p.pos+1 < len(p.text) && p.text[p.pos+1] == '\'' {
+				p.pos++
+				continue
+			}
+			p.pos++
+			return parseYAMLScalar(p.text[start:p.pos])
+		}
+		return nil, fmt.Errorf("unterminated string in %s", p.text)
+	}
+	for p.pos < len(p.text) {
+		c := p.text[p.pos]
+		if c == ',' || c == ']' || c == '}' {
+			break
+		}
+		if inMapping && c == ':' && (p.pos+1 == len(p.text) || strings.IndexByte(" ,}", p.text[p.pos+1]) >= 0) {
+			break
+		}
+		p.pos++
+	}
+	plain := strings.TrimSpace(p.text[start:p.pos])
+	if plain == "" {
+		return nil, fmt.Errorf("empty flow entry in %s", p.text)
+	}
+	return parseYAMLScalar(plain)
+}
+
+func (p *yamlFlowParser) skipSpace() {
+	for p.pos < len(p.text) && (p.text[p.pos] == ' ' || p.text[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func stripYAMLComment(line string) string {
+	quote := byte(0)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if quote == '"' && c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.IndexByte(" [{,-", line[i-1]) >= 0 {
+				quote = c
+			}
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
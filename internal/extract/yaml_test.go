@@ -0,0 +1,85 @@
+package extract
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []any
+	}{
+		{
+			name:    "flow mapping value",
+			content: "card: {class: p-4, title: \"Hi, there\"}\n",
+			want:    []any{map[string]any{"card": map[string]any{"class": "p-4", "title": "Hi, there"}}},
+		},
+		{
+			name:    "flow mappings in a sequence",
+			content: "items:\n  - {class: 'p-2 m-1'}\n  - {className: [flex, \"gap-2\"]}\n",
+			want: []any{map[string]any{"items": []any{
+				map[string]any{"class": "p-2 m-1"},
+				map[string]any{"className": []any{"flex", "gap-2"}},
+			}}},
+		},
+		{
+			name:    "flow sequence with nested quotes",
+			content: "tags: [\"say \\\"hi\\\", ok\", 'it''s, fine', [nested, list]]\n",
+			want:    []any{map[string]any{"tags": []any{"say \"hi\", ok", "it's, fine", []any{"nested", "list"}}}},
+		},
+		{
+			name:    "literal block scalar",
+			content: "class: |\n  p-4\n  bg-white\nnext: x\n",
+			want:    []any{map[string]any{"class": "p-4 bg-white", "next": "x"}},
+		},
+		{
+			name:    "folded block scalar",
+			content: "class: >-\n  rounded-md\n  shadow-md\n",
+			want:    []any{map[string]any{"class": "rounded-md shadow-md"}},
+		},
+		{
+			name:    "multiple documents",
+			content: "---\nclass: p-4\n---\nclass: m-2\n...\n---\n- flex\n",
+			want:    []any{map[string]any{"class": "p-4"}, map[string]any{"class": "m-2"}, []any{"flex"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeYAML(tt.content)
+			if err != nil {
+				t.Fatalf("decodeYAML: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeYAML = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeYAMLRejectsUnsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "multi-line flow mapping", content: "card: {class: p-4,\n  title: x}\n", err: "unterminated flow collection"},
+		{name: "unterminated string", content: "tags: [\"p-4, m-2]\n", err: "unterminated string"},
+		{name: "text after flow collection", content: "tags: [p-4] m-2\n", err: "after flow collection"},
+		{name: "top-level flow mapping", content: "---\n{class: p-4}\n", err: "expected key: value"},
+		{name: "alias in flow sequence", content: "tags: [*base]\n", err: "anchors and aliases"},
+		{name: "merge key", content: "card:\n  <<: x\n", err: "merge keys"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeYAML(tt.content)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("decodeYAML error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}